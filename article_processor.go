@@ -7,6 +7,7 @@ import (
 	"image/png"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/golang/freetype/truetype"
 )
@@ -40,6 +41,14 @@ func NewArticleProcessor(config *Config, contentDir, configDir, configPath strin
 	}
 }
 
+// ArticleContent holds the resolved content rendered into an OGP image.
+type ArticleContent struct {
+	Title       string            // Resolved title text
+	Description string            // Resolved description text
+	Texts       map[string]string // Resolved text of the named text elements
+	Tags        []string          // Article tags rendered as tag chips
+}
+
 // ProcessOptions controls how articles are processed.
 type ProcessOptions struct {
 	TestMode  bool   // Generate test output to temporary location
@@ -60,6 +69,12 @@ func (ap *ArticleProcessor) ProcessArticle(articlePath string, options ProcessOp
 	if err != nil {
 		return err
 	}
+	content := &ArticleContent{
+		Title:       title,
+		Description: description,
+		Texts:       ap.determineTextElements(fm, finalConfig),
+		Tags:        fm.Tags,
+	}
 
 	// Generate appropriate output path
 	outputPath, err := ap.generateOutputPath(finalConfig, fm, articlePath, options)
@@ -69,11 +84,11 @@ func (ap *ArticleProcessor) ProcessArticle(articlePath string, options ProcessOp
 
 	// Handle test mode output
 	if options.TestMode {
-		ap.handleTestModeOutput(finalConfig, fm, articlePath, title, description, options.OutputDir)
+		ap.handleTestModeOutput(finalConfig, fm, articlePath, content, options.OutputDir)
 	}
 
 	// Generate the OGP image
-	err = ap.generateImage(title, description, outputPath, finalConfig, articlePath, fm.OGP, options.TestMode)
+	err = ap.generateImage(content, outputPath, finalConfig, articlePath, options.TestMode)
 	if err != nil {
 		return NewRenderError("OGP image", err)
 	}
@@ -219,20 +234,16 @@ func (ap *ArticleProcessor) generateProductionOutputPath(config *Config, fm *Fro
 	return outputPath, nil
 }
 
-// generateImage creates the OGP image by compositing background, overlays, and text.
-// It handles both config-level and article-level overlay compositions.
-func (ap *ArticleProcessor) generateImage(title, description, outputPath string, config *Config, articlePath string, ogpSettings *OGPFrontMatter, testMode bool) error {
-	dst, font, err := ap.setupImageCanvas(config, articlePath)
+// generateImage creates the OGP image by composing the background, overlay and text layers.
+// Layers are drawn in z order so that any element can be placed above or below the others.
+func (ap *ArticleProcessor) generateImage(content *ArticleContent, outputPath string, config *Config, articlePath string, testMode bool) error {
+	dst, background, font, err := ap.setupImageCanvas(config, articlePath)
 	if err != nil {
 		return err
 	}
 
-	err = ap.applyOverlays(dst, config, articlePath, ogpSettings)
-	if err != nil {
-		return err
-	}
-
-	err = ap.renderTextElements(dst, font, config, title, description, testMode)
+	scene := ap.buildScene(background, font, config, content, articlePath, testMode)
+	err = scene.Render(dst)
 	if err != nil {
 		return err
 	}
@@ -240,11 +251,11 @@ func (ap *ArticleProcessor) generateImage(title, description, outputPath string,
 	return ap.saveImage(dst, outputPath)
 }
 
-// setupImageCanvas creates the base image canvas with background.
-func (ap *ArticleProcessor) setupImageCanvas(config *Config, articlePath string) (*image.RGBA, *truetype.Font, error) {
+// setupImageCanvas creates an empty canvas sized to the background and loads the font.
+func (ap *ArticleProcessor) setupImageCanvas(config *Config, articlePath string) (*image.RGBA, image.Image, *truetype.Font, error) {
 	backgroundImage, err := ap.bgProcessor.CreateBackground(config, articlePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create background: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create background: %w", err)
 	}
 
 	// Load font for title (nil means auto-detect)
@@ -254,52 +265,131 @@ func (ap *ArticleProcessor) setupImageCanvas(config *Config, articlePath string)
 	}
 	font, err := ap.fontManager.LoadFont(titleFontPath, articlePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to load font: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to load font: %w", err)
 	}
 
 	// Note: Currently using the same font for both title and description
 	// In the future, we could support different fonts for each text element
 
 	bounds := backgroundImage.Bounds()
-	dst := image.NewRGBA(bounds)
-	draw.Draw(dst, bounds, backgroundImage, image.Point{}, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
 
-	return dst, font, nil
+	return dst, backgroundImage, font, nil
 }
 
-// applyOverlays applies both config-level and article-level overlays to the image.
-func (ap *ArticleProcessor) applyOverlays(dst *image.RGBA, config *Config, articlePath string, ogpSettings *OGPFrontMatter) error {
-	// Check if overlay should be rendered
-	if !config.Overlay.Visible || config.Overlay.Image == nil || *config.Overlay.Image == "" {
+// buildScene creates the layers of the OGP image from the merged configuration.
+func (ap *ArticleProcessor) buildScene(background image.Image, font *truetype.Font, config *Config, content *ArticleContent, articlePath string, testMode bool) *Scene {
+	scene := NewScene()
+
+	scene.AddLayer("background", config.Background.Z, func(dst *image.RGBA) error {
+		draw.Draw(dst, dst.Bounds(), background, background.Bounds().Min, draw.Over)
 		return nil
+	})
+
+	ap.addShapeLayers(scene, config)
+	ap.addOverlayLayers(scene, config, articlePath)
+	ap.addTextLayers(scene, font, config, content.Title, content.Description, testMode)
+	ap.addTextElementLayers(scene, font, config, content.Texts, articlePath, testMode)
+	ap.addTagsLayer(scene, font, config, content.Tags, articlePath, testMode)
+
+	return scene
+}
+
+// addShapeLayers adds a layer for each visible shape.
+func (ap *ArticleProcessor) addShapeLayers(scene *Scene, config *Config) {
+	for i := range config.Shapes {
+		shape := config.Shapes[i]
+		if !shape.Visible {
+			continue
+		}
+
+		name := "shape"
+		if shape.ID != "" {
+			name = fmt.Sprintf("shape '%s'", shape.ID)
+		}
+
+		scene.AddLayer(name, shape.Z, func(dst *image.RGBA) error {
+			if err := drawShape(dst, &shape); err != nil {
+				DefaultLogger.Warning("Failed to draw %s: %v", name, err)
+			}
+			return nil
+		})
 	}
+}
 
-	// The config already contains merged overlay settings from all sources
-	// (defaults -> global -> type -> front matter), so just use the final config
-	err := compositeCustomImage(dst, articlePath, &config.Overlay, false, ap.configDir)
-	if err != nil {
-		DefaultLogger.Warning("Failed to composite overlay: %v", err)
+// addOverlayLayers adds a layer for each visible overlay.
+// The config already contains merged overlay settings from all sources
+// (defaults -> global -> type -> front matter), so just use the final config
+func (ap *ArticleProcessor) addOverlayLayers(scene *Scene, config *Config, articlePath string) {
+	for _, overlay := range visibleOverlays(config) {
+		overlay := overlay
+		name := "overlay"
+		if overlay.ID != "" {
+			name = fmt.Sprintf("overlay '%s'", overlay.ID)
+		}
+
+		scene.AddLayer(name, overlay.Z, func(dst *image.RGBA) error {
+			err := compositeCustomImage(dst, articlePath, &overlay, false, ap.configDir)
+			if err != nil {
+				DefaultLogger.Warning("Failed to composite %s: %v", name, err)
+			}
+			return nil
+		})
 	}
+}
 
-	return nil
+// addTextLayers adds layers for the title and description if they are visible and not empty.
+func (ap *ArticleProcessor) addTextLayers(scene *Scene, font *truetype.Font, config *Config, title, description string, testMode bool) {
+	if config.Title.Visible && title != "" {
+		scene.AddLayer("title", config.Title.Z, func(dst *image.RGBA) error {
+			return ap.imageRenderer.RenderTextElement(dst, font, &config.Title, title, testMode, "title")
+		})
+	}
+
+	if config.Description.Visible && description != "" {
+		scene.AddLayer("description", config.Description.Z, func(dst *image.RGBA) error {
+			return ap.imageRenderer.RenderTextElement(dst, font, &config.Description, description, testMode, "description")
+		})
+	}
 }
 
-// renderTextElements renders title and description text onto the image.
-func (ap *ArticleProcessor) renderTextElements(dst *image.RGBA, font *truetype.Font, config *Config, title, description string, testMode bool) error {
-	renderOptions := &RenderOptions{
-		Font:        font,
-		Config:      config,
-		Title:       title,
-		Description: description,
-		TestMode:    testMode,
+// addTextElementLayers adds a layer for each named text element with content.
+// Elements are added in name order so that elements with the same z index render deterministically.
+func (ap *ArticleProcessor) addTextElementLayers(scene *Scene, font *truetype.Font, config *Config, texts map[string]string, articlePath string, testMode bool) {
+	for _, name := range sortedTextElementNames(config.Texts) {
+		text := texts[name]
+		if text == "" {
+			continue
+		}
+
+		textConfig := config.Texts[name]
+		elementFont := font
+		if textConfig.Font != nil && *textConfig.Font != "" {
+			elementFont = ap.fontManager.LoadFontWithFallback(*textConfig.Font, articlePath, font)
+		}
+
+		name := name
+		scene.AddLayer(fmt.Sprintf("text '%s'", name), textConfig.Z, func(dst *image.RGBA) error {
+			return ap.imageRenderer.RenderTextElement(dst, elementFont, &textConfig, text, testMode, name)
+		})
 	}
+}
 
-	err := ap.imageRenderer.RenderTextOnImage(dst, renderOptions)
-	if err != nil {
-		return fmt.Errorf("failed to render text: %w", err)
+// addTagsLayer adds the tag chips layer if tag chips are visible and the article has tags.
+func (ap *ArticleProcessor) addTagsLayer(scene *Scene, font *truetype.Font, config *Config, tags []string, articlePath string, testMode bool) {
+	if !config.Tags.Visible || len(tags) == 0 {
+		return
 	}
 
-	return nil
+	tagsFont := font
+	if config.Tags.Font != nil && *config.Tags.Font != "" {
+		tagsFont = ap.fontManager.LoadFontWithFallback(*config.Tags.Font, articlePath, font)
+	}
+
+	scene.AddLayer("tags", config.Tags.Z, func(dst *image.RGBA) error {
+		ap.imageRenderer.RenderTagChips(dst, tagsFont, &config.Tags, tags, testMode)
+		return nil
+	})
 }
 
 // saveImage writes the generated image to the specified path as PNG.
@@ -337,9 +427,82 @@ func (ap *ArticleProcessor) parseAndConfigureArticle(articlePath string) (*Front
 		return nil, nil, NewConfigError(fmt.Sprintf("failed to build configuration for %s", indexPath), err)
 	}
 
+	ap.applyBackgroundSource(fm, finalConfig, articlePath)
+	ap.resolveGenerativeSeed(fm, finalConfig)
+	ap.resolveTextAssetPaths(finalConfig, articlePath)
+
 	return fm, finalConfig, nil
 }
 
+// resolveTextAssetPaths resolves text fill texture, code font and highlight font paths with fallback from the article to the config directory.
+// Text is rendered without the article path, so the paths are resolved before rendering.
+func (ap *ArticleProcessor) resolveTextAssetPaths(config *Config, articlePath string) {
+	resolve := func(textConfig *TextConfig) {
+		if textConfig.Fill.Image != "" {
+			textConfig.Fill.Image = resolveAssetPath(textConfig.Fill.Image, ap.configDir, articlePath)
+		}
+		resolveFont := func(fontPath *string) *string {
+			if fontPath == nil || *fontPath == "" {
+				return fontPath
+			}
+			resolved := resolveAssetPath(*fontPath, ap.configDir, articlePath)
+			return &resolved
+		}
+		textConfig.Markup.CodeFont = resolveFont(textConfig.Markup.CodeFont)
+		for i := range textConfig.Highlights {
+			textConfig.Highlights[i].Font = resolveFont(textConfig.Highlights[i].Font)
+		}
+	}
+
+	resolve(&config.Title)
+	resolve(&config.Description)
+	for name, element := range config.Texts {
+		resolve(&element)
+		config.Texts[name] = element
+	}
+}
+
+// resolveGenerativeSeed replaces the generative seed template with its value for the article.
+// An empty seed uses the slug front matter field, then the title.
+func (ap *ArticleProcessor) resolveGenerativeSeed(fm *FrontMatter, config *Config) {
+	generative := &config.Background.Generative
+	if generative.Type == "" {
+		return
+	}
+
+	seed := ""
+	if generative.Seed != "" {
+		seed = ap.processContentTemplate(generative.Seed, fm)
+	}
+	if seed == "" {
+		if slug, ok := fm.Fields["slug"].(string); ok {
+			seed = slug
+		}
+	}
+	if seed == "" {
+		seed = fm.Title
+	}
+	generative.Seed = seed
+}
+
+// applyBackgroundSource replaces the background image with the article's cover image when the
+// cover source is selected. The configured background is kept when no cover image is found.
+func (ap *ArticleProcessor) applyBackgroundSource(fm *FrontMatter, config *Config, articlePath string) {
+	switch config.Background.Source {
+	case "", BackgroundSourceConfig:
+		return
+	case BackgroundSourceCover:
+	default:
+		DefaultLogger.Warning("Unknown background source '%s', using the configured background", config.Background.Source)
+		return
+	}
+
+	staticDir := filepath.Join(ap.getHugoRootPath(), StaticDirectory)
+	if cover := ap.bgProcessor.FindCoverImage(&config.Background.Cover, fm, articlePath, staticDir); cover != "" {
+		config.Background.Image = &cover
+	}
+}
+
 // determineArticleContent resolves title and description text and validates content exists
 func (ap *ArticleProcessor) determineArticleContent(fm *FrontMatter, config *Config) (string, string, error) {
 	title := ap.determineText(fm, &config.Title, fm.Title)
@@ -362,9 +525,25 @@ func (ap *ArticleProcessor) generateOutputPath(config *Config, fm *FrontMatter,
 	return ap.generateProductionOutputPath(config, fm, articlePath, options.OutputDir)
 }
 
+// determineTextElements resolves the text of each visible named text element.
+// Elements without a content template or with empty content are omitted.
+func (ap *ArticleProcessor) determineTextElements(fm *FrontMatter, config *Config) map[string]string {
+	texts := make(map[string]string, len(config.Texts))
+	for name, textConfig := range config.Texts {
+		if !textConfig.Visible {
+			continue
+		}
+		textConfig := textConfig
+		if text := ap.determineText(fm, &textConfig, ""); text != "" {
+			texts[name] = text
+		}
+	}
+	return texts
+}
+
 // handleTestModeOutput prints configuration and path information in test mode
-func (ap *ArticleProcessor) handleTestModeOutput(config *Config, fm *FrontMatter, articlePath, title, description, outputDir string) {
-	ap.printUsedConfig(config, title, description)
+func (ap *ArticleProcessor) handleTestModeOutput(config *Config, fm *FrontMatter, articlePath string, content *ArticleContent, outputDir string) {
+	ap.printUsedConfig(config, content)
 	ap.printOutputPaths(config, fm, articlePath, outputDir)
 }
 
@@ -385,14 +564,17 @@ func (ap *ArticleProcessor) logSuccess(articlePath, outputPath string, testMode
 }
 
 // printUsedConfig prints the configuration used for OGP generation in test mode.
-func (ap *ArticleProcessor) printUsedConfig(config *Config, title, description string) {
+func (ap *ArticleProcessor) printUsedConfig(config *Config, content *ArticleContent) {
 	fmt.Println("\n=== Configuration Used for OGP Generation ===")
 
 	ap.printImageConfig()
 	ap.printOutputConfig(config)
 	ap.printBackgroundConfig(config)
-	ap.printTitleConfig(config, title)
-	ap.printDescriptionConfig(config, description)
+	ap.printTitleConfig(config, content.Title)
+	ap.printDescriptionConfig(config, content.Description)
+	ap.printTextElementsConfig(config, content.Texts)
+	ap.printTagsConfig(config, content.Tags)
+	ap.printShapesConfig(config)
 	ap.printOverlayConfig(config)
 
 	fmt.Println("\n=== End Configuration ===")
@@ -416,11 +598,68 @@ func (ap *ArticleProcessor) printOutputConfig(config *Config) {
 // printBackgroundConfig prints background configuration details
 func (ap *ArticleProcessor) printBackgroundConfig(config *Config) {
 	fmt.Println("\nBackground:")
+	fmt.Printf("  Source: %s\n", config.Background.Source)
 	if config.Background.Image != nil && *config.Background.Image != "" {
 		fmt.Printf("  Image: %s\n", *config.Background.Image)
+		fmt.Printf("  Fit: %s (focal point: %.2f,%.2f)\n", config.Background.Fit, config.Background.FocalPoint.X, config.Background.FocalPoint.Y)
 	} else {
 		fmt.Printf("  Color: %s\n", config.Background.Color)
 	}
+	for _, filter := range config.Background.Filters {
+		fmt.Printf("  Filter: %s", filter.Type)
+		if filter.Amount != nil {
+			fmt.Printf(" (amount: %.2f)", *filter.Amount)
+		}
+		if filter.Color != "" {
+			fmt.Printf(" (color: %s)", filter.Color)
+		}
+		if filter.Opacity != nil {
+			fmt.Printf(" (opacity: %.2f)", *filter.Opacity)
+		}
+		if filter.Shadow != "" || filter.Highlight != "" {
+			fmt.Printf(" (shadow: %s, highlight: %s)", filter.Shadow, filter.Highlight)
+		}
+		fmt.Println()
+	}
+	if generative := config.Background.Generative; generative.Type != "" {
+		fmt.Printf("  Generative: %s (seed: %q, count: %d)\n", generative.Type, generative.Seed, generative.Count)
+		fmt.Printf("    Palette: %s\n", strings.Join(generative.Palette, ", "))
+	}
+	if config.Background.Gradient.Type != "" {
+		ap.printGradientConfig(&config.Background.Gradient, "  ")
+	}
+	if pattern := config.Background.Pattern; pattern.Type != "" {
+		fmt.Printf("  Pattern: %s (color: %s, size: %d, thickness: %.1f, angle: %.1f)\n",
+			pattern.Type, pattern.Color, pattern.Size, pattern.Thickness, pattern.Angle)
+	}
+	fmt.Printf("  Z: %d\n", config.Background.Z)
+}
+
+// printGradientConfig prints gradient configuration details with the given indentation
+func (ap *ArticleProcessor) printGradientConfig(gradient *GradientConfig, indent string) {
+	fmt.Printf("%sGradient: %s (angle: %.1f, center: %.2f,%.2f, dither: %t)\n",
+		indent, gradient.Type, gradient.Angle, gradient.CenterX, gradient.CenterY, gradient.Dither)
+	for _, stop := range gradient.Stops {
+		if stop.Position != nil {
+			fmt.Printf("%s  Stop: %s at %.2f\n", indent, stop.Color, *stop.Position)
+		} else {
+			fmt.Printf("%s  Stop: %s\n", indent, stop.Color)
+		}
+	}
+}
+
+// printTextFillConfig prints text fill configuration details if a gradient or texture is set
+func (ap *ArticleProcessor) printTextFillConfig(fill *TextFillConfig) {
+	if fill.Gradient.Type == "" && fill.Image == "" {
+		return
+	}
+
+	fmt.Printf("  Fill: relative to %s\n", fill.Relative)
+	if fill.Gradient.Type != "" {
+		ap.printGradientConfig(&fill.Gradient, "    ")
+	} else {
+		fmt.Printf("    Image: %s\n", fill.Image)
+	}
 }
 
 // printTitleConfig prints title configuration details
@@ -435,6 +674,77 @@ func (ap *ArticleProcessor) printDescriptionConfig(config *Config, description s
 	ap.printTextConfigDetails(&config.Description, description)
 }
 
+// printTextElementsConfig prints the configuration of the named text elements
+func (ap *ArticleProcessor) printTextElementsConfig(config *Config, texts map[string]string) {
+	for _, name := range sortedTextElementNames(config.Texts) {
+		textConfig := config.Texts[name]
+		fmt.Printf("\nText '%s':\n", name)
+		ap.printTextConfigDetails(&textConfig, texts[name])
+	}
+}
+
+// printShapesConfig prints shape configuration details
+func (ap *ArticleProcessor) printShapesConfig(config *Config) {
+	for i, shape := range config.Shapes {
+		id := shape.ID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+		}
+		fmt.Printf("\nShapes[%s]:\n", id)
+		fmt.Printf("  Visible: %t\n", shape.Visible)
+		if !shape.Visible {
+			continue
+		}
+		fmt.Printf("  Type: %s\n", shape.Type)
+		if shape.Type == ShapeLine || shape.Type == ShapePolygon {
+			fmt.Printf("  Points: %v\n", shape.Points)
+		} else {
+			fmt.Printf("  Box: X=%d, Y=%d, Width=%d, Height=%d\n", shape.X, shape.Y, shape.Width, shape.Height)
+		}
+		if shape.Type == ShapeRoundedRect {
+			fmt.Printf("  Radius: %.1f\n", shape.Radius)
+		}
+		fmt.Printf("  Fill: %s\n", shape.Fill)
+		fmt.Printf("  Stroke: %s (width %.1f)\n", shape.Stroke, shape.StrokeWidth)
+		fmt.Printf("  Opacity: %.2f\n", shape.Opacity)
+		fmt.Printf("  Z: %d\n", shape.Z)
+	}
+}
+
+// printTagsConfig prints tag chips configuration details
+func (ap *ArticleProcessor) printTagsConfig(config *Config, tags []string) {
+	fmt.Println("\nTags:")
+	fmt.Printf("  Visible: %t\n", config.Tags.Visible)
+	if !config.Tags.Visible {
+		return
+	}
+
+	fmt.Printf("  Tags: %q\n", tags)
+	if config.Tags.Font != nil && *config.Tags.Font != "" {
+		fmt.Printf("  Font: %s\n", *config.Tags.Font)
+	} else {
+		fmt.Printf("  Font: (title font)\n")
+	}
+	fmt.Printf("  Size: %.1f\n", config.Tags.Size)
+	fmt.Printf("  Weight: %d\n", config.Tags.Weight)
+	fmt.Printf("  Color: %s\n", config.Tags.Color)
+	fmt.Printf("  Background: %s\n", config.Tags.Background)
+	for _, tag := range sortedTagColorNames(config.Tags.Colors) {
+		colors := config.Tags.Colors[tag]
+		fmt.Printf("  Colors[%s]: color=%s background=%s\n", tag, colors.Color, colors.Background)
+	}
+	fmt.Printf("  Area: X=%d, Y=%d, Width=%d, Height=%d\n",
+		config.Tags.Area.X, config.Tags.Area.Y,
+		config.Tags.Area.Width, config.Tags.Area.Height)
+	fmt.Printf("  Line Alignment: %s\n", config.Tags.LineAlignment)
+	fmt.Printf("  Padding: %dx%d\n", config.Tags.PaddingX, config.Tags.PaddingY)
+	fmt.Printf("  Radius: %.1f\n", config.Tags.Radius)
+	fmt.Printf("  Gap: %d\n", config.Tags.Gap)
+	fmt.Printf("  Max: %d\n", config.Tags.Max)
+	fmt.Printf("  Show More: %t\n", config.Tags.ShowMore)
+	fmt.Printf("  Z: %d\n", config.Tags.Z)
+}
+
 // printTextConfigDetails prints common text configuration details (shared by title and description)
 func (ap *ArticleProcessor) printTextConfigDetails(textConfig *TextConfig, defaultText string) {
 	fmt.Printf("  Visible: %t\n", textConfig.Visible)
@@ -453,18 +763,59 @@ func (ap *ArticleProcessor) printTextConfigDetails(textConfig *TextConfig, defau
 	if textConfig.Font != nil && *textConfig.Font != "" {
 		fmt.Printf("  Font: %s\n", *textConfig.Font)
 	} else {
-		fmt.Printf("  Font: (auto-detect)\n")
+		fmt.Printf("  Font: (auto-detect: %s)\n", ap.fontManager.DefaultFontSource())
 	}
 
 	// Print text styling configuration
 	fmt.Printf("  Size: %.1f\n", textConfig.Size)
+	fmt.Printf("  Weight: %d\n", textConfig.Weight)
+	fmt.Printf("  Width: %.1f\n", textConfig.Width)
+	fmt.Printf("  Italic: %t\n", textConfig.Italic)
 	fmt.Printf("  Color: %s\n", textConfig.Color)
 	fmt.Printf("  Block Position: %s\n", textConfig.BlockPosition)
 	fmt.Printf("  Line Alignment: %s\n", textConfig.LineAlignment)
 	fmt.Printf("  Overflow: %s\n", textConfig.Overflow)
 	fmt.Printf("  Min Size: %.1f\n", textConfig.MinSize)
+	fmt.Printf("  Max Size: %.1f\n", textConfig.MaxSize)
+	fmt.Printf("  Wrap: %s\n", textConfig.Wrap)
 	fmt.Printf("  Line Height: %.2f\n", textConfig.LineHeight)
 	fmt.Printf("  Letter Spacing: %d\n", textConfig.LetterSpacing)
+	fmt.Printf("  Kerning: %t\n", textConfig.Kerning)
+	ap.printTextFillConfig(&textConfig.Fill)
+	fmt.Printf("  Stroke: Width=%.1f, Color=%s\n", textConfig.Stroke.Width, textConfig.Stroke.Color)
+	fmt.Printf("  Shadow: Offset=(%d, %d), Blur=%.1f, Color=%s\n",
+		textConfig.Shadow.OffsetX, textConfig.Shadow.OffsetY, textConfig.Shadow.Blur, textConfig.Shadow.Color)
+	fmt.Printf("  Glow: Radius=%.1f, Color=%s\n", textConfig.Glow.Radius, textConfig.Glow.Color)
+	if background := textConfig.LineBackground; background.Color != "" {
+		fmt.Printf("  Line Background: Color=%s, Padding=%d,%d, Radius=%.1f, Opacity=%.2f\n",
+			background.Color, background.PaddingX, background.PaddingY, background.Radius, background.Opacity)
+	}
+	if panel := textConfig.Panel; hasTextPanel(&panel) {
+		fmt.Printf("  Panel: Color=%s, Padding=%d,%d, Radius=%.1f, Border=%.1f %s\n",
+			panel.Color, panel.PaddingX, panel.PaddingY, panel.Radius, panel.BorderWidth, panel.BorderColor)
+		fmt.Printf("    Shadow: Offset=(%d, %d), Blur=%.1f, Color=%s\n",
+			panel.Shadow.OffsetX, panel.Shadow.OffsetY, panel.Shadow.Blur, panel.Shadow.Color)
+	}
+	if markup := textConfig.Markup; markup.Enabled {
+		codeFont := "Go Mono"
+		if markup.CodeFont != nil && *markup.CodeFont != "" {
+			codeFont = *markup.CodeFont
+		}
+		fmt.Printf("  Markup: Code Font=%s\n", codeFont)
+		fmt.Printf("    Bold: Color=%s, Background=%s\n", markup.Bold.Color, markup.Bold.Background)
+		fmt.Printf("    Italic: Color=%s, Background=%s\n", markup.Italic.Color, markup.Italic.Background)
+		fmt.Printf("    Code: Color=%s, Background=%s\n", markup.Code.Color, markup.Code.Background)
+		fmt.Printf("    Highlight: Color=%s, Background=%s\n", markup.Highlight.Color, markup.Highlight.Background)
+	}
+	for i, rule := range textConfig.Highlights {
+		fmt.Printf("  Highlight %d: Pattern=%q, Words=%q, Ignore Case=%t\n", i+1, rule.Pattern, rule.Words, rule.IgnoreCase)
+		font := "text font"
+		if rule.Font != nil && *rule.Font != "" {
+			font = *rule.Font
+		}
+		fmt.Printf("    Color=%s, Background=%s, Font=%s\n", rule.Color, rule.Background, font)
+	}
+	fmt.Printf("  Z: %d\n", textConfig.Z)
 
 	// Print area configuration
 	fmt.Printf("  Area: X=%d, Y=%d, Width=%d, Height=%d\n",
@@ -491,9 +842,33 @@ func (ap *ArticleProcessor) printOverlayConfig(config *Config) {
 		ap.printOverlayPlacement(config.Overlay.Placement)
 		fmt.Printf("  Fit: %s\n", config.Overlay.Fit)
 		fmt.Printf("  Opacity: %.2f\n", config.Overlay.Opacity)
+		fmt.Printf("  Blend: %s\n", config.Overlay.Blend)
+		fmt.Printf("  Z: %d\n", config.Overlay.Z)
 	} else {
 		fmt.Printf("  Image: (none)\n")
 	}
+
+	for i, overlay := range config.Overlays {
+		id := overlay.ID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+		}
+		fmt.Printf("\nOverlays[%s]:\n", id)
+		fmt.Printf("  Visible: %t\n", overlay.Visible)
+		if !overlay.Visible {
+			continue
+		}
+		if overlay.Image != nil && *overlay.Image != "" {
+			fmt.Printf("  Image: %s\n", *overlay.Image)
+		} else {
+			fmt.Printf("  Image: (none)\n")
+		}
+		ap.printOverlayPlacement(overlay.Placement)
+		fmt.Printf("  Fit: %s\n", overlay.Fit)
+		fmt.Printf("  Opacity: %.2f\n", overlay.Opacity)
+		fmt.Printf("  Blend: %s\n", overlay.Blend)
+		fmt.Printf("  Z: %d\n", overlay.Z)
+	}
 }
 
 // printOverlayPlacement prints overlay placement configuration details
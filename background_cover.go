@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// coverImageExtensions lists the image formats that can be decoded as backgrounds.
+var coverImageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+// FindCoverImage returns the cover image of an article, or an empty string when none is found.
+// Front matter fields are checked first, then page bundle files matching the globs.
+// Site-absolute paths such as "/images/cover.jpg" are looked up in staticDir.
+// The returned path can be used as the background image of the article.
+func (bp *BackgroundProcessor) FindCoverImage(cover *CoverSourceConfig, fm *FrontMatter, articlePath, staticDir string) string {
+	for _, field := range cover.Fields {
+		value := frontMatterImageField(fm.Fields, field)
+		if value == "" || strings.Contains(value, "://") {
+			continue
+		}
+		if path := bp.resolveCoverPath(value, articlePath, staticDir); path != "" {
+			return path
+		}
+	}
+
+	if articlePath == "" {
+		return ""
+	}
+	for _, pattern := range cover.Globs {
+		matches, err := filepath.Glob(filepath.Join(articlePath, pattern))
+		if err != nil {
+			DefaultLogger.Warning("Invalid cover image pattern '%s': %v", pattern, err)
+			continue
+		}
+		for _, match := range matches {
+			if !coverImageExtensions[strings.ToLower(filepath.Ext(match))] || !isRegularFile(match) {
+				continue
+			}
+			if rel, err := filepath.Rel(articlePath, match); err == nil {
+				return rel
+			}
+		}
+	}
+
+	return ""
+}
+
+// resolveCoverPath returns a usable path for a front matter image value, or an empty string
+// when the file does not exist.
+func (bp *BackgroundProcessor) resolveCoverPath(value, articlePath, staticDir string) string {
+	if strings.HasPrefix(value, "/") {
+		if staticDir != "" {
+			if path := filepath.Join(staticDir, filepath.FromSlash(value)); isRegularFile(path) {
+				return path
+			}
+		}
+		if isRegularFile(value) {
+			return value
+		}
+		value = strings.TrimPrefix(value, "/")
+	}
+
+	if isRegularFile(bp.pathResolver.ResolveAssetPath(value, articlePath)) {
+		return value
+	}
+	return ""
+}
+
+// frontMatterImageField looks up a dotted field path in the front matter fields.
+// Lists such as Hugo's "images" yield their first non-empty entry.
+func frontMatterImageField(fields map[string]interface{}, path string) string {
+	var value interface{} = fields
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// isRegularFile reports whether path exists and is not a directory.
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBackgroundProcessor_FindCoverImage tests the lookup order of front matter fields and bundle files.
+func TestBackgroundProcessor_FindCoverImage(t *testing.T) {
+	root := t.TempDir()
+	configDir := filepath.Join(root, "config")
+	staticDir := filepath.Join(root, "static")
+	articleDir := filepath.Join(root, "content", "post")
+	for _, dir := range []string{configDir, filepath.Join(staticDir, "images"), filepath.Join(articleDir, "img")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+	writeSolidPNG(t, filepath.Join(articleDir, "img", "hero.png"), color.RGBA{A: 255})
+	writeSolidPNG(t, filepath.Join(articleDir, "featured.png"), color.RGBA{A: 255})
+	writeSolidPNG(t, filepath.Join(staticDir, "images", "site.png"), color.RGBA{A: 255})
+	writeSolidPNG(t, filepath.Join(configDir, "shared.png"), color.RGBA{A: 255})
+	if err := os.WriteFile(filepath.Join(articleDir, "featured.txt"), []byte("not an image"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		fields   map[string]interface{}
+		cover    CoverSourceConfig
+		expected string
+	}{
+		{
+			name:     "first entry of images list",
+			fields:   map[string]interface{}{"images": []interface{}{"img/hero.png", "other.png"}},
+			cover:    CoverSourceConfig{Fields: DefaultCoverFields, Globs: DefaultCoverGlobs},
+			expected: "img/hero.png",
+		},
+		{
+			name:     "nested field",
+			fields:   map[string]interface{}{"cover": map[string]interface{}{"image": "img/hero.png"}},
+			cover:    CoverSourceConfig{Fields: []string{"cover.image"}},
+			expected: "img/hero.png",
+		},
+		{
+			name:     "site-absolute path in static directory",
+			fields:   map[string]interface{}{"image": "/images/site.png"},
+			cover:    CoverSourceConfig{Fields: []string{"image"}},
+			expected: filepath.Join(staticDir, "images", "site.png"),
+		},
+		{
+			name:     "config directory fallback",
+			fields:   map[string]interface{}{"image": "shared.png"},
+			cover:    CoverSourceConfig{Fields: []string{"image"}},
+			expected: "shared.png",
+		},
+		{
+			name:     "missing and remote fields fall through to bundle files",
+			fields:   map[string]interface{}{"images": []interface{}{"https://example.com/a.png"}, "image": "missing.png"},
+			cover:    CoverSourceConfig{Fields: []string{"images", "image"}, Globs: []string{"featured.*"}},
+			expected: "featured.png",
+		},
+		{
+			name:     "nothing found",
+			fields:   map[string]interface{}{},
+			cover:    CoverSourceConfig{Fields: []string{"image"}, Globs: []string{"cover.*"}},
+			expected: "",
+		},
+	}
+
+	processor := NewBackgroundProcessor(configDir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := &FrontMatter{Fields: tt.fields}
+			if got := processor.FindCoverImage(&tt.cover, fm, articleDir, staticDir); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestArticleProcessor_ApplyBackgroundSource tests that the cover image replaces the configured background.
+func TestArticleProcessor_ApplyBackgroundSource(t *testing.T) {
+	root := t.TempDir()
+	articleDir := filepath.Join(root, "content", "post")
+	if err := os.MkdirAll(articleDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	writeSolidPNG(t, filepath.Join(articleDir, "cover.png"), color.RGBA{R: 255, A: 255})
+
+	ap := &ArticleProcessor{
+		contentDir:  filepath.Join(root, "content"),
+		bgProcessor: NewBackgroundProcessor(root),
+	}
+	fm := &FrontMatter{Fields: map[string]interface{}{}}
+
+	config := getDefaultConfig()
+	config.Background.Image = stringPtr("default.png")
+	ap.applyBackgroundSource(fm, config, articleDir)
+	if *config.Background.Image != "default.png" {
+		t.Errorf("Expected configured image with the config source, got %s", *config.Background.Image)
+	}
+
+	config.Background.Source = BackgroundSourceCover
+	ap.applyBackgroundSource(fm, config, articleDir)
+	if *config.Background.Image != "cover.png" {
+		t.Errorf("Expected cover image, got %s", *config.Background.Image)
+	}
+
+	config = getDefaultConfig()
+	config.Background.Source = BackgroundSourceCover
+	ap.applyBackgroundSource(fm, config, root)
+	if config.Background.Image != nil {
+		t.Errorf("Expected color background when no cover is found, got %s", *config.Background.Image)
+	}
+}
+
+// TestMergeConfigsWithSettings_BackgroundSource tests the merge of the background source settings.
+func TestMergeConfigsWithSettings_BackgroundSource(t *testing.T) {
+	global := &ConfigSettings{
+		Background: &BackgroundSettings{
+			Source: stringPtr(BackgroundSourceCover),
+			Cover:  &CoverSourceSettings{Fields: []string{"banner"}},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Background: &BackgroundOverride{
+			Cover: &CoverSourceSettings{Globs: []string{"hero.*"}},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	if config.Background.Source != BackgroundSourceCover {
+		t.Errorf("Expected cover source, got %s", config.Background.Source)
+	}
+	if !equalStrings(config.Background.Cover.Fields, []string{"banner"}) {
+		t.Errorf("Expected fields [banner], got %v", config.Background.Cover.Fields)
+	}
+	if !equalStrings(config.Background.Cover.Globs, []string{"hero.*"}) {
+		t.Errorf("Expected globs [hero.*], got %v", config.Background.Cover.Globs)
+	}
+
+	defaults := getDefaultConfig()
+	defaults.Background.Cover.Fields[0] = "changed"
+	if DefaultCoverFields[0] != "images" {
+		t.Error("Expected default cover fields to be copied")
+	}
+}
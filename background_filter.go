@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// fitBackgroundImage scales an image to the canvas size (1200x630 pixels) according to the fit method.
+// Cover crops around the focal point, contain centers the image over the background color
+// and fill stretches the image. The image is returned unchanged for "none" and, with a warning,
+// for unknown fit methods.
+func (bp *BackgroundProcessor) fitBackgroundImage(src image.Image, background *BackgroundConfig) (image.Image, error) {
+	switch background.Fit {
+	case "", BackgroundFitNone:
+		return src, nil
+	case BackgroundFitCover, BackgroundFitContain, BackgroundFitFill:
+	default:
+		DefaultLogger.Warning("Unknown background fit '%s', using %s", background.Fit, BackgroundFitNone)
+		return src, nil
+	}
+
+	srcBounds := src.Bounds()
+	if srcBounds.Empty() {
+		return nil, NewValidationError("background image is empty")
+	}
+
+	base, err := bp.createColorBackground(background.Color)
+	if err != nil {
+		return nil, err
+	}
+	canvas := base.(*image.RGBA)
+	width, height := canvas.Bounds().Dx(), canvas.Bounds().Dy()
+
+	srcWidth, srcHeight := float64(srcBounds.Dx()), float64(srcBounds.Dy())
+	scaleX, scaleY := float64(width)/srcWidth, float64(height)/srcHeight
+
+	switch background.Fit {
+	case BackgroundFitFill:
+		scaled := imaging.Resize(src, width, height, imaging.Lanczos)
+		draw.Draw(canvas, canvas.Bounds(), scaled, image.Point{}, draw.Over)
+
+	case BackgroundFitCover:
+		scale := math.Max(scaleX, scaleY)
+		// Rounding must never leave the scaled image smaller than the canvas
+		scaledWidth := int(math.Max(float64(width), math.Round(srcWidth*scale)))
+		scaledHeight := int(math.Max(float64(height), math.Round(srcHeight*scale)))
+		scaled := imaging.Resize(src, scaledWidth, scaledHeight, imaging.Lanczos)
+
+		offset := image.Point{
+			X: int(math.Round(float64(scaledWidth-width) * clampUnit(background.FocalPoint.X))),
+			Y: int(math.Round(float64(scaledHeight-height) * clampUnit(background.FocalPoint.Y))),
+		}
+		draw.Draw(canvas, canvas.Bounds(), scaled, offset, draw.Over)
+
+	case BackgroundFitContain:
+		scale := math.Min(scaleX, scaleY)
+		scaledWidth := int(math.Max(1, math.Min(float64(width), math.Round(srcWidth*scale))))
+		scaledHeight := int(math.Max(1, math.Min(float64(height), math.Round(srcHeight*scale))))
+		scaled := imaging.Resize(src, scaledWidth, scaledHeight, imaging.Lanczos)
+
+		x, y := (width-scaledWidth)/2, (height-scaledHeight)/2
+		draw.Draw(canvas, image.Rect(x, y, x+scaledWidth, y+scaledHeight), scaled, image.Point{}, draw.Over)
+	}
+
+	return canvas, nil
+}
+
+// applyBackgroundFilters applies the filters in order to a copy of the image.
+func applyBackgroundFilters(src image.Image, filters []BackgroundFilter) (image.Image, error) {
+	if len(filters) == 0 {
+		return src, nil
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(img, img.Bounds(), src, bounds.Min, draw.Src)
+
+	for i := range filters {
+		filtered, err := applyBackgroundFilter(img, &filters[i])
+		if err != nil {
+			return nil, err
+		}
+		img = filtered
+	}
+
+	return img, nil
+}
+
+// applyBackgroundFilter applies a single filter, modifying img in place where possible.
+func applyBackgroundFilter(img *image.RGBA, filter *BackgroundFilter) (*image.RGBA, error) {
+	amount := func(defaultAmount float64) float64 {
+		if filter.Amount != nil {
+			return *filter.Amount
+		}
+		return defaultAmount
+	}
+
+	switch filter.Type {
+	case FilterBlur:
+		radius := amount(DefaultBlurRadius)
+		if radius <= 0 {
+			return img, nil
+		}
+		blurred := imaging.Blur(img, radius)
+		result := image.NewRGBA(img.Bounds())
+		draw.Draw(result, result.Bounds(), blurred, image.Point{}, draw.Src)
+		return result, nil
+
+	case FilterDarken:
+		strength := clampUnit(amount(DefaultDarkenAmount))
+		mapBackgroundPixels(img, func(c [3]float64) [3]float64 {
+			for k := range c {
+				c[k] *= 1 - strength
+			}
+			return c
+		})
+
+	case FilterBrighten:
+		strength := clampUnit(amount(DefaultBrightenAmount))
+		mapBackgroundPixels(img, func(c [3]float64) [3]float64 {
+			for k := range c {
+				c[k] += (255 - c[k]) * strength
+			}
+			return c
+		})
+
+	case FilterGrayscale:
+		strength := clampUnit(amount(DefaultGrayscaleAmount))
+		mapBackgroundPixels(img, func(c [3]float64) [3]float64 {
+			luminance := luminanceOf(c)
+			for k := range c {
+				c[k] += (luminance - c[k]) * strength
+			}
+			return c
+		})
+
+	case FilterDuotone:
+		shadow, err := parseFilterColor(filter.Shadow, DefaultDuotoneShadow)
+		if err != nil {
+			return nil, err
+		}
+		highlight, err := parseFilterColor(filter.Highlight, DefaultDuotoneHighlight)
+		if err != nil {
+			return nil, err
+		}
+		mapBackgroundPixels(img, func(c [3]float64) [3]float64 {
+			t := luminanceOf(c) / 255
+			for k := range c {
+				c[k] = shadow[k] + (highlight[k]-shadow[k])*t
+			}
+			return c
+		})
+
+	case FilterTint:
+		tint, err := parseFilterColor(filter.Color, DefaultTintColor)
+		if err != nil {
+			return nil, err
+		}
+		opacity := DefaultTintOpacity
+		if filter.Opacity != nil {
+			opacity = *filter.Opacity
+		}
+		// The alpha of a #RRGGBBAA tint color scales the opacity
+		opacity = clampUnit(opacity) * tint[3] / 255
+		mapBackgroundPixels(img, func(c [3]float64) [3]float64 {
+			for k := range c {
+				c[k] += (tint[k] - c[k]) * opacity
+			}
+			return c
+		})
+
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unknown background filter: %s", filter.Type))
+	}
+
+	return img, nil
+}
+
+// mapBackgroundPixels replaces the color of every pixel with the result of fn.
+// fn receives and returns non-premultiplied channels in the range 0-255; alpha is preserved.
+func mapBackgroundPixels(img *image.RGBA, fn func(c [3]float64) [3]float64) {
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		alpha := img.Pix[i+3]
+		if alpha == 0 {
+			continue
+		}
+
+		scale := 255 / float64(alpha)
+		mapped := fn([3]float64{
+			float64(img.Pix[i]) * scale,
+			float64(img.Pix[i+1]) * scale,
+			float64(img.Pix[i+2]) * scale,
+		})
+		for k, v := range mapped {
+			img.Pix[i+k] = uint8(math.Round(clampUnit(v/255) * float64(alpha)))
+		}
+	}
+}
+
+// luminanceOf returns the Rec. 709 luma of an sRGB color in the range 0-255.
+func luminanceOf(c [3]float64) float64 {
+	return 0.2126*c[0] + 0.7152*c[1] + 0.0722*c[2]
+}
+
+// parseFilterColor parses a filter color, using the default when it is empty.
+func parseFilterColor(hex, defaultHex string) ([4]float64, error) {
+	if hex == "" {
+		hex = defaultHex
+	}
+	c, err := parseHexColor(hex)
+	if err != nil {
+		return [4]float64{}, NewValidationError(fmt.Sprintf("invalid filter color '%s': %v", hex, err))
+	}
+	return [4]float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}, nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBackgroundProcessor_FitBackgroundImage tests the canvas size and crop of each fit method.
+func TestBackgroundProcessor_FitBackgroundImage(t *testing.T) {
+	processor := NewBackgroundProcessor("/test")
+
+	// Left half red, right half blue, at twice the canvas width
+	src := image.NewRGBA(image.Rect(0, 0, DefaultImageWidth*2, DefaultImageHeight))
+	for y := 0; y < DefaultImageHeight; y++ {
+		for x := 0; x < DefaultImageWidth*2; x++ {
+			if x < DefaultImageWidth {
+				src.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				src.SetRGBA(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		fit      string
+		focalX   float64
+		point    image.Point
+		expected color.RGBA
+	}{
+		{name: "cover focal left", fit: BackgroundFitCover, focalX: 0, point: image.Point{X: DefaultImageWidth - 10, Y: 300}, expected: color.RGBA{R: 255, A: 255}},
+		{name: "cover focal right", fit: BackgroundFitCover, focalX: 1, point: image.Point{X: 10, Y: 300}, expected: color.RGBA{B: 255, A: 255}},
+		{name: "contain letterbox", fit: BackgroundFitContain, focalX: 0.5, point: image.Point{X: 10, Y: 10}, expected: color.RGBA{G: 255, A: 255}},
+		{name: "fill stretches", fit: BackgroundFitFill, focalX: 0.5, point: image.Point{X: 10, Y: 10}, expected: color.RGBA{R: 255, A: 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			background := BackgroundConfig{
+				Color:      "#00FF00",
+				Fit:        tt.fit,
+				FocalPoint: FocalPointConfig{X: tt.focalX, Y: 0.5},
+			}
+
+			fitted, err := processor.fitBackgroundImage(src, &background)
+			if err != nil {
+				t.Fatalf("fitBackgroundImage failed: %v", err)
+			}
+			bounds := fitted.Bounds()
+			if bounds.Dx() != DefaultImageWidth || bounds.Dy() != DefaultImageHeight {
+				t.Fatalf("Expected %dx%d canvas, got %v", DefaultImageWidth, DefaultImageHeight, bounds)
+			}
+			if got := fitted.(*image.RGBA).RGBAAt(tt.point.X, tt.point.Y); got != tt.expected {
+				t.Errorf("Expected %v at %v, got %v", tt.expected, tt.point, got)
+			}
+		})
+	}
+
+	none := BackgroundConfig{Fit: BackgroundFitNone}
+	if fitted, _ := processor.fitBackgroundImage(src, &none); fitted != image.Image(src) {
+		t.Error("Expected fit none to return the image unchanged")
+	}
+	invalid := BackgroundConfig{Fit: "zoom"}
+	var fitted image.Image
+	output := captureOutput(func() { fitted, _ = processor.fitBackgroundImage(src, &invalid) })
+	if fitted != image.Image(src) || !strings.Contains(output, "Unknown background fit 'zoom'") {
+		t.Errorf("Expected an unknown fit to warn and return the image unchanged, got %q", output)
+	}
+}
+
+// TestApplyBackgroundFilters tests the color filters.
+func TestApplyBackgroundFilters(t *testing.T) {
+	src := color.RGBA{R: 200, G: 100, B: 50, A: 255}
+
+	tests := []struct {
+		name     string
+		filter   BackgroundFilter
+		expected color.RGBA
+	}{
+		{name: "darken", filter: BackgroundFilter{Type: FilterDarken, Amount: float64Ptr(0.5)}, expected: color.RGBA{R: 100, G: 50, B: 25, A: 255}},
+		{name: "brighten", filter: BackgroundFilter{Type: FilterBrighten, Amount: float64Ptr(1)}, expected: color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{name: "grayscale", filter: BackgroundFilter{Type: FilterGrayscale}, expected: color.RGBA{R: 118, G: 118, B: 118, A: 255}},
+		{name: "duotone", filter: BackgroundFilter{Type: FilterDuotone, Shadow: "#000000", Highlight: "#FF0000"}, expected: color.RGBA{R: 118, A: 255}},
+		{name: "tint", filter: BackgroundFilter{Type: FilterTint, Color: "#0000FF", Opacity: float64Ptr(0.5)}, expected: color.RGBA{R: 100, G: 50, B: 153, A: 255}},
+		{name: "translucent tint color", filter: BackgroundFilter{Type: FilterTint, Color: "#0000FF80", Opacity: float64Ptr(1)}, expected: color.RGBA{R: 100, G: 50, B: 153, A: 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := applyBackgroundFilters(newFilledRGBA(4, 4, src), []BackgroundFilter{tt.filter})
+			if err != nil {
+				t.Fatalf("applyBackgroundFilters failed: %v", err)
+			}
+			got := filtered.(*image.RGBA).RGBAAt(1, 1)
+			if absDiff(got.R, tt.expected.R) > 1 || absDiff(got.G, tt.expected.G) > 1 || absDiff(got.B, tt.expected.B) > 1 || got.A != tt.expected.A {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := applyBackgroundFilters(newFilledRGBA(1, 1, src), []BackgroundFilter{{Type: "sepia"}}); err == nil {
+		t.Error("Expected error for an unknown filter")
+	}
+}
+
+// TestApplyBackgroundFilters_Blur tests that blurring softens edges without changing the size.
+func TestApplyBackgroundFilters_Blur(t *testing.T) {
+	img := newFilledRGBA(40, 10, color.RGBA{A: 255})
+	for y := 0; y < 10; y++ {
+		for x := 20; x < 40; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+
+	filtered, err := applyBackgroundFilters(img, []BackgroundFilter{{Type: FilterBlur, Amount: float64Ptr(3)}})
+	if err != nil {
+		t.Fatalf("applyBackgroundFilters failed: %v", err)
+	}
+	if filtered.Bounds() != img.Bounds() {
+		t.Errorf("Expected bounds %v, got %v", img.Bounds(), filtered.Bounds())
+	}
+	if edge := filtered.(*image.RGBA).RGBAAt(19, 5).R; edge == 0 || edge == 255 {
+		t.Errorf("Expected blurred edge to be gray, got %d", edge)
+	}
+	if img.RGBAAt(19, 5).R != 0 {
+		t.Error("Expected source image to remain unchanged")
+	}
+}
+
+// TestBackgroundProcessor_CreateBackground_FitAndFilters tests fit and filters applied to a loaded image.
+func TestBackgroundProcessor_CreateBackground_FitAndFilters(t *testing.T) {
+	dir := t.TempDir()
+	writeSolidPNG(t, filepath.Join(dir, "photo.png"), color.RGBA{R: 200, G: 200, B: 200, A: 255})
+
+	config := getDefaultConfig()
+	config.Background.Image = stringPtr("photo.png")
+	config.Background.Fit = BackgroundFitCover
+	config.Background.Filters = []BackgroundFilter{{Type: FilterDarken, Amount: float64Ptr(0.5)}}
+
+	processor := NewBackgroundProcessor(dir)
+	background, err := processor.CreateBackground(config, dir)
+	if err != nil {
+		t.Fatalf("CreateBackground failed: %v", err)
+	}
+	if bounds := background.Bounds(); bounds.Dx() != DefaultImageWidth || bounds.Dy() != DefaultImageHeight {
+		t.Errorf("Expected %dx%d background, got %v", DefaultImageWidth, DefaultImageHeight, bounds)
+	}
+	if r, _, _, _ := background.At(600, 300).RGBA(); r>>8 != 100 {
+		t.Errorf("Expected darkened pixel 100, got %d", r>>8)
+	}
+}
+
+// TestMergeConfigsWithSettings_BackgroundFilters tests the merge of fit, focal point and filters.
+func TestMergeConfigsWithSettings_BackgroundFilters(t *testing.T) {
+	global := &ConfigSettings{
+		Background: &BackgroundSettings{
+			Fit:        stringPtr(BackgroundFitCover),
+			FocalPoint: &FocalPointSettings{Y: float64Ptr(0.2)},
+			Filters:    []BackgroundFilter{{Type: FilterBlur}, {Type: FilterDarken}},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Background: &BackgroundOverride{
+			FocalPoint: &FocalPointSettings{X: float64Ptr(0.8)},
+			Filters:    []BackgroundFilter{{Type: FilterGrayscale, Amount: float64Ptr(0.5)}},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	if config.Background.Fit != BackgroundFitCover {
+		t.Errorf("Expected fit cover, got %s", config.Background.Fit)
+	}
+	if config.Background.FocalPoint != (FocalPointConfig{X: 0.8, Y: 0.2}) {
+		t.Errorf("Expected focal point 0.8,0.2, got %+v", config.Background.FocalPoint)
+	}
+	if len(config.Background.Filters) != 1 || config.Background.Filters[0].Type != FilterGrayscale {
+		t.Errorf("Expected front matter filters to replace inherited filters, got %+v", config.Background.Filters)
+	}
+
+	*config.Background.Filters[0].Amount = 1
+	if *fm.Background.Filters[0].Amount != 0.5 {
+		t.Error("Expected merged filters to be copied from front matter")
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// generativeRand is a splitmix64 pseudo-random generator.
+// It is used instead of math/rand so that the sequence for a seed, and therefore the
+// generated pixels, can never change between Go versions.
+type generativeRand struct {
+	state uint64
+}
+
+// newGenerativeRand creates a generator seeded from the FNV-1a hash of seed.
+func newGenerativeRand(seed string) *generativeRand {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	return &generativeRand{state: h.Sum64()}
+}
+
+// next returns the next 64-bit value of the sequence.
+func (r *generativeRand) next() uint64 {
+	r.state += 0x9E3779B97F4A7C15
+	z := r.state
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// float returns a value in the range [0, 1).
+func (r *generativeRand) float() float64 {
+	return float64(r.next()>>11) / (1 << 53)
+}
+
+// between returns a value in the range [lo, hi).
+func (r *generativeRand) between(lo, hi float64) float64 {
+	return lo + (hi-lo)*r.float()
+}
+
+// intn returns a value in the range [0, n).
+func (r *generativeRand) intn(n int) int {
+	return int(r.next() % uint64(n))
+}
+
+// createGenerativeBackground renders a generative background at the given size.
+// The same configuration and seed always produce the same pixels on the same architecture.
+// The random sequence is exact, but the compiler may fuse floating-point multiplies and adds
+// differently per architecture, so pixels can differ in the last bit of a color across them.
+func createGenerativeBackground(generative *GenerativeConfig, width, height int) (*image.RGBA, error) {
+	if len(generative.Palette) == 0 {
+		return nil, NewValidationError("generative background requires at least one palette color")
+	}
+	palette := make([][4]float64, len(generative.Palette))
+	for i, hex := range generative.Palette {
+		c, err := parseHexColor(hex)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("invalid generative palette color '%s': %v", hex, err))
+		}
+		palette[i] = [4]float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+	}
+
+	rng := newGenerativeRand(generative.Seed)
+	count := func(defaultCount int) int {
+		if generative.Count > 0 {
+			return generative.Count
+		}
+		return defaultCount
+	}
+
+	switch generative.Type {
+	case GenerativeMesh:
+		return drawMeshGradient(rng, palette, count(DefaultMeshPoints), width, height), nil
+	case GenerativeBlobs:
+		return drawBlobs(rng, palette, count(DefaultBlobCount), width, height), nil
+	case GenerativeTiles:
+		return drawTiles(rng, palette, count(DefaultTileRows), width, height), nil
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unknown generative background type: %s", generative.Type))
+	}
+}
+
+// drawMeshGradient blends palette colors between randomly placed points
+// using inverse distance weighting, which gives a soft mesh gradient.
+func drawMeshGradient(rng *generativeRand, palette [][4]float64, points, width, height int) *image.RGBA {
+	type meshPoint struct {
+		x, y  float64
+		color [4]float64
+	}
+
+	// Colors cycle through the palette from a random offset so that every color appears.
+	// Points may lie slightly outside so that the edges are not dominated by one color
+	offset := rng.intn(len(palette))
+	mesh := make([]meshPoint, points)
+	for i := range mesh {
+		mesh[i] = meshPoint{
+			x:     rng.between(-0.1, 1.1) * float64(width),
+			y:     rng.between(-0.1, 1.1) * float64(height),
+			color: palette[(offset+i)%len(palette)],
+		}
+	}
+
+	diagonal := math.Hypot(float64(width), float64(height))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var c [4]float64
+			total := 0.0
+			for _, point := range mesh {
+				d := math.Hypot(float64(x)+0.5-point.x, float64(y)+0.5-point.y) / diagonal
+				weight := 1 / math.Pow(d*d+0.01, 1.5)
+				for k := range c {
+					c[k] += point.color[k] * weight
+				}
+				total += weight
+			}
+			for k := range c {
+				c[k] /= total
+			}
+			img.SetRGBA(x, y, premultiplyDithered(c, (bayerMatrix[y%8][x%8]+0.5)/64))
+		}
+	}
+	return img
+}
+
+// drawBlobs draws soft translucent circles over the first palette color.
+func drawBlobs(rng *generativeRand, palette [][4]float64, blobs, width, height int) *image.RGBA {
+	// Accumulate in floating point so that overlapping soft edges do not band
+	pixels := make([][4]float64, width*height)
+	for i := range pixels {
+		pixels[i] = palette[0]
+	}
+
+	for i := 0; i < blobs; i++ {
+		radius := rng.between(0.2, 0.55) * float64(height)
+		cx := rng.between(0, 1) * float64(width)
+		cy := rng.between(0, 1) * float64(height)
+		blob := palette[rng.intn(len(palette))]
+		opacity := rng.between(0.45, 0.85) * blob[3] / 255
+		softness := radius * 0.6
+
+		bounds := boundsOf(cx-radius, cy-radius, cx+radius, cy+radius, 1).Intersect(image.Rect(0, 0, width, height))
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+				alpha := opacity * (1 - smoothstep(radius-softness, radius, d))
+				if alpha <= 0 {
+					continue
+				}
+				p := &pixels[y*width+x]
+				for k := 0; k < 3; k++ {
+					p[k] += (blob[k] - p[k]) * alpha
+				}
+				p[3] += (255 - p[3]) * alpha
+			}
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, premultiplyDithered(pixels[y*width+x], (bayerMatrix[y%8][x%8]+0.5)/64))
+		}
+	}
+	return img
+}
+
+// drawTiles fills a grid of square tiles, each with a background color and a geometric motif:
+// a quarter circle, a triangle, a circle or a half square.
+func drawTiles(rng *generativeRand, palette [][4]float64, rows, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	size := int(math.Ceil(float64(height) / float64(rows)))
+	if size < 1 {
+		size = 1
+	}
+
+	toColor := func(c [4]float64) color.NRGBA {
+		return color.NRGBA{R: uint8(c[0]), G: uint8(c[1]), B: uint8(c[2]), A: uint8(c[3])}
+	}
+
+	for top := 0; top < height; top += size {
+		for left := 0; left < width; left += size {
+			cell := image.Rect(left, top, left+size, top+size)
+			backIndex := rng.intn(len(palette))
+			foreIndex := backIndex
+			if len(palette) > 1 {
+				foreIndex = (backIndex + 1 + rng.intn(len(palette)-1)) % len(palette)
+			}
+			draw.Draw(img, cell, image.NewUniform(toColor(palette[backIndex])), image.Point{}, draw.Over)
+
+			x0, y0 := float64(cell.Min.X), float64(cell.Min.Y)
+			x1, y1 := float64(cell.Max.X), float64(cell.Max.Y)
+			s := float64(size)
+			corners := []ShapePoint{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+			corner := rng.intn(4)
+
+			mask := image.NewAlpha(cell)
+			unionPath(mask, cell, func(p *pathWriter) {
+				switch rng.intn(4) {
+				case 0:
+					// Quarter circle centered on a corner; the rasterizer clips it to the cell
+					c := corners[corner]
+					p.ellipsePath(c.X, c.Y, s, s)
+				case 1:
+					// Triangle covering half of the cell
+					p.polygonPath([]ShapePoint{corners[corner], corners[(corner+1)%4], corners[(corner+2)%4]})
+				case 2:
+					p.ellipsePath(x0+s/2, y0+s/2, s*0.35, s*0.35)
+				default:
+					// Half of the cell, split horizontally or vertically
+					if corner%2 == 0 {
+						p.polygonPath([]ShapePoint{{X: x0, Y: y0}, {X: x0 + s/2, Y: y0}, {X: x0 + s/2, Y: y1}, {X: x0, Y: y1}})
+					} else {
+						p.polygonPath([]ShapePoint{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y0 + s/2}, {X: x0, Y: y0 + s/2}})
+					}
+				}
+			})
+			draw.DrawMask(img, cell, image.NewUniform(toColor(palette[foreIndex])), image.Point{}, mask, cell.Min, draw.Over)
+		}
+	}
+	return img
+}
+
+// smoothstep returns a smooth transition from 0 at edge0 to 1 at edge1.
+func smoothstep(edge0, edge1, x float64) float64 {
+	if edge1 <= edge0 {
+		if x < edge0 {
+			return 0
+		}
+		return 1
+	}
+	t := clampUnit((x - edge0) / (edge1 - edge0))
+	return t * t * (3 - 2*t)
+}
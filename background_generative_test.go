@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestCreateGenerativeBackground_Deterministic tests that each type produces stable pixels per seed.
+func TestCreateGenerativeBackground_Deterministic(t *testing.T) {
+	for _, generativeType := range []string{GenerativeMesh, GenerativeBlobs, GenerativeTiles} {
+		t.Run(generativeType, func(t *testing.T) {
+			generative := GenerativeConfig{Type: generativeType, Seed: "my-first-post", Palette: DefaultGenerativePalette}
+
+			first, err := createGenerativeBackground(&generative, 120, 63)
+			if err != nil {
+				t.Fatalf("createGenerativeBackground failed: %v", err)
+			}
+			second, err := createGenerativeBackground(&generative, 120, 63)
+			if err != nil {
+				t.Fatalf("createGenerativeBackground failed: %v", err)
+			}
+			if !bytes.Equal(first.Pix, second.Pix) {
+				t.Error("Expected the same seed to produce the same pixels")
+			}
+
+			generative.Seed = "another-post"
+			other, err := createGenerativeBackground(&generative, 120, 63)
+			if err != nil {
+				t.Fatalf("createGenerativeBackground failed: %v", err)
+			}
+			if bytes.Equal(first.Pix, other.Pix) {
+				t.Error("Expected different seeds to produce different pixels")
+			}
+
+			for i := 3; i < len(first.Pix); i += 4 {
+				if first.Pix[i] != 255 {
+					t.Fatalf("Expected an opaque background with an opaque palette, got alpha %d", first.Pix[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGenerativeRand_Sequence tests that the generator sequence for a seed never changes.
+func TestGenerativeRand_Sequence(t *testing.T) {
+	rng := newGenerativeRand("")
+	// FNV-1a offset basis followed by splitmix64
+	expected := []uint64{0xc3817c016ba4ff30, 0x100cdaacc0bc9316}
+	for i, want := range expected {
+		if got := rng.next(); got != want {
+			t.Errorf("Value %d: expected %#x, got %#x", i, want, got)
+		}
+	}
+}
+
+// TestCreateGenerativeBackground_Invalid tests validation of the type and palette.
+func TestCreateGenerativeBackground_Invalid(t *testing.T) {
+	tests := []GenerativeConfig{
+		{Type: "noise", Palette: DefaultGenerativePalette},
+		{Type: GenerativeMesh},
+		{Type: GenerativeMesh, Palette: []string{"teal"}},
+	}
+	for _, generative := range tests {
+		generative := generative
+		if _, err := createGenerativeBackground(&generative, 10, 10); err == nil {
+			t.Errorf("Expected error for %+v", generative)
+		}
+	}
+}
+
+// TestArticleProcessor_ResolveGenerativeSeed tests the seed priority of template, slug and title.
+func TestArticleProcessor_ResolveGenerativeSeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     string
+		fm       *FrontMatter
+		expected string
+	}{
+		{
+			name:     "template",
+			seed:     "{{.Title | upper}}",
+			fm:       &FrontMatter{Title: "Hello"},
+			expected: "HELLO",
+		},
+		{
+			name:     "slug field",
+			fm:       &FrontMatter{Title: "Hello", Fields: map[string]interface{}{"slug": "hello-world"}},
+			expected: "hello-world",
+		},
+		{
+			name:     "title",
+			fm:       &FrontMatter{Title: "Hello"},
+			expected: "Hello",
+		},
+	}
+
+	ap := &ArticleProcessor{templateProcessor: NewTemplateProcessor()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getDefaultConfig()
+			config.Background.Generative.Type = GenerativeTiles
+			config.Background.Generative.Seed = tt.seed
+
+			ap.resolveGenerativeSeed(tt.fm, config)
+			if got := config.Background.Generative.Seed; got != tt.expected {
+				t.Errorf("Expected seed %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestMergeConfigsWithSettings_Generative tests the merge of generative background settings.
+func TestMergeConfigsWithSettings_Generative(t *testing.T) {
+	global := &ConfigSettings{
+		Background: &BackgroundSettings{
+			Generative: &GenerativeSettings{
+				Type:    stringPtr(GenerativeBlobs),
+				Palette: []string{"#000000", "#FFFFFF"},
+			},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Background: &BackgroundOverride{
+			Generative: &GenerativeSettings{Count: intPtr(3), Seed: stringPtr("fixed")},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	generative := config.Background.Generative
+
+	if generative.Type != GenerativeBlobs || generative.Count != 3 || generative.Seed != "fixed" {
+		t.Errorf("Unexpected merged generative config %+v", generative)
+	}
+	if !equalStrings(generative.Palette, []string{"#000000", "#FFFFFF"}) {
+		t.Errorf("Expected global palette, got %v", generative.Palette)
+	}
+
+	background, err := NewBackgroundProcessor("/test").CreateBackground(config, "")
+	if err != nil {
+		t.Fatalf("CreateBackground failed: %v", err)
+	}
+	if bounds := background.Bounds(); bounds.Dx() != DefaultImageWidth || bounds.Dy() != DefaultImageHeight {
+		t.Errorf("Expected %dx%d background, got %v", DefaultImageWidth, DefaultImageHeight, bounds)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+// bayerMatrix is the 8x8 ordered dithering threshold matrix.
+var bayerMatrix = [8][8]float64{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// gradientStop is a parsed color stop with a resolved position.
+type gradientStop struct {
+	color    [4]float64 // Non-premultiplied RGBA in the range 0-255
+	position float64
+}
+
+// createGradientBackground renders a gradient at the given size.
+// Colors are interpolated in sRGB like CSS gradients and optionally dithered.
+func createGradientBackground(gradient *GradientConfig, width, height int) (*image.RGBA, error) {
+	stops, err := parseGradientStops(gradient.Stops)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := gradientPositionFunc(gradient, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Sample at the pixel center
+			c := interpolateGradient(stops, position(float64(x)+0.5, float64(y)+0.5))
+
+			threshold := 0.5
+			if gradient.Dither {
+				threshold = (bayerMatrix[y%8][x%8] + 0.5) / 64
+			}
+			img.SetRGBA(x, y, premultiplyDithered(c, threshold))
+		}
+	}
+
+	return img, nil
+}
+
+// gradientPositionFunc returns a function mapping pixel coordinates to a position
+// along the gradient, where 0 is the first stop and 1 is the last stop.
+func gradientPositionFunc(gradient *GradientConfig, width, height int) (func(x, y float64) float64, error) {
+	w, h := float64(width), float64(height)
+	angle := gradient.Angle * math.Pi / 180
+
+	switch gradient.Type {
+	case GradientLinear:
+		// Like CSS, the gradient line passes through the center and its length
+		// makes the corners in the gradient direction reach the first and last stop
+		dx, dy := math.Sin(angle), -math.Cos(angle)
+		length := math.Abs(w*dx) + math.Abs(h*dy)
+		cx, cy := w/2, h/2
+		return func(x, y float64) float64 {
+			return ((x-cx)*dx+(y-cy)*dy)/length + 0.5
+		}, nil
+
+	case GradientRadial:
+		// A circle reaching the farthest corner
+		cx, cy := w*gradient.CenterX, h*gradient.CenterY
+		radius := math.Max(math.Hypot(cx, cy), math.Max(math.Hypot(w-cx, cy), math.Max(math.Hypot(cx, h-cy), math.Hypot(w-cx, h-cy))))
+		return func(x, y float64) float64 {
+			return math.Hypot(x-cx, y-cy) / radius
+		}, nil
+
+	case GradientConic:
+		cx, cy := w*gradient.CenterX, h*gradient.CenterY
+		return func(x, y float64) float64 {
+			// 0 points up and angles increase clockwise
+			theta := math.Atan2(x-cx, cy-y) - angle
+			turns := theta / (2 * math.Pi)
+			return turns - math.Floor(turns)
+		}, nil
+
+	default:
+		return nil, NewValidationError(fmt.Sprintf("unknown gradient type: %s", gradient.Type))
+	}
+}
+
+// parseGradientStops parses stop colors and resolves missing positions.
+// Stops without a position are spaced evenly between their neighbours, and
+// positions are clamped so that they never decrease.
+func parseGradientStops(stops []GradientStop) ([]gradientStop, error) {
+	if len(stops) == 0 {
+		return nil, NewValidationError("gradient requires at least one color stop")
+	}
+
+	parsed := make([]gradientStop, len(stops))
+	for i, stop := range stops {
+		c, err := parseHexColor(stop.Color)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("invalid gradient stop color '%s': %v", stop.Color, err))
+		}
+		parsed[i].color = [4]float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+		parsed[i].position = math.NaN()
+		if stop.Position != nil {
+			parsed[i].position = *stop.Position
+		}
+	}
+
+	if math.IsNaN(parsed[0].position) {
+		parsed[0].position = 0
+	}
+	if last := len(parsed) - 1; last > 0 && math.IsNaN(parsed[last].position) {
+		parsed[last].position = 1
+	}
+
+	for i := 1; i < len(parsed); i++ {
+		if math.IsNaN(parsed[i].position) {
+			// Find the next stop with a position and spread the missing ones evenly
+			next := i + 1
+			for math.IsNaN(parsed[next].position) {
+				next++
+			}
+			start, end := parsed[i-1].position, parsed[next].position
+			for j := i; j < next; j++ {
+				parsed[j].position = start + (end-start)*float64(j-i+1)/float64(next-i+1)
+			}
+		}
+		if parsed[i].position < parsed[i-1].position {
+			parsed[i].position = parsed[i-1].position
+		}
+	}
+
+	return parsed, nil
+}
+
+// interpolateGradient returns the color at position t.
+func interpolateGradient(stops []gradientStop, t float64) [4]float64 {
+	if t <= stops[0].position {
+		return stops[0].color
+	}
+
+	for i := 1; i < len(stops); i++ {
+		if t <= stops[i].position {
+			a, b := stops[i-1], stops[i]
+			span := b.position - a.position
+			if span <= 0 {
+				return b.color
+			}
+			f := (t - a.position) / span
+			var c [4]float64
+			for k := range c {
+				c[k] = a.color[k] + (b.color[k]-a.color[k])*f
+			}
+			return c
+		}
+	}
+
+	return stops[len(stops)-1].color
+}
+
+// premultiplyDithered converts a non-premultiplied color in the range 0-255 to RGBA,
+// rounding each channel up when its fractional part exceeds the threshold.
+func premultiplyDithered(c [4]float64, threshold float64) color.RGBA {
+	quantize := func(v float64) uint8 {
+		v = math.Floor(v + threshold)
+		if v < 0 {
+			return 0
+		}
+		if v > 255 {
+			return 255
+		}
+		return uint8(v)
+	}
+
+	alpha := quantize(c[3])
+	scale := float64(alpha) / 255
+	premultiplied := func(v float64) uint8 {
+		// Premultiplied channels must never exceed alpha
+		q := quantize(v * scale)
+		if q > alpha {
+			return alpha
+		}
+		return q
+	}
+
+	return color.RGBA{
+		R: premultiplied(c[0]),
+		G: premultiplied(c[1]),
+		B: premultiplied(c[2]),
+		A: alpha,
+	}
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// TestParseGradientStops tests that missing stop positions are resolved.
+func TestParseGradientStops(t *testing.T) {
+	tests := []struct {
+		name     string
+		stops    []GradientStop
+		expected []float64
+	}{
+		{
+			name:     "evenly spaced",
+			stops:    []GradientStop{{Color: "#000000"}, {Color: "#808080"}, {Color: "#FFFFFF"}},
+			expected: []float64{0, 0.5, 1},
+		},
+		{
+			name:     "spread between explicit positions",
+			stops:    []GradientStop{{Color: "#000000"}, {Color: "#111111"}, {Color: "#222222"}, {Color: "#333333", Position: float64Ptr(0.6)}},
+			expected: []float64{0, 0.2, 0.4, 0.6},
+		},
+		{
+			name:     "decreasing position clamped",
+			stops:    []GradientStop{{Color: "#000000", Position: float64Ptr(0.5)}, {Color: "#FFFFFF", Position: float64Ptr(0.2)}},
+			expected: []float64{0.5, 0.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stops, err := parseGradientStops(tt.stops)
+			if err != nil {
+				t.Fatalf("parseGradientStops failed: %v", err)
+			}
+			for i, stop := range stops {
+				if math.Abs(stop.position-tt.expected[i]) > 1e-9 {
+					t.Errorf("Stop %d: expected position %v, got %v", i, tt.expected[i], stop.position)
+				}
+			}
+		})
+	}
+
+	if _, err := parseGradientStops(nil); err == nil {
+		t.Error("Expected error for a gradient without stops")
+	}
+	if _, err := parseGradientStops([]GradientStop{{Color: "blue"}}); err == nil {
+		t.Error("Expected error for an invalid stop color")
+	}
+}
+
+// TestCreateGradientBackground_Linear tests the direction of linear gradients.
+func TestCreateGradientBackground_Linear(t *testing.T) {
+	gradient := GradientConfig{
+		Type:  GradientLinear,
+		Angle: 90,
+		Stops: []GradientStop{{Color: "#000000"}, {Color: "#FFFFFF"}},
+	}
+
+	img, err := createGradientBackground(&gradient, 100, 10)
+	if err != nil {
+		t.Fatalf("createGradientBackground failed: %v", err)
+	}
+
+	left, right := img.RGBAAt(0, 5), img.RGBAAt(99, 5)
+	if left.R > 5 || right.R < 250 {
+		t.Errorf("Expected black to white from left to right, got %v and %v", left, right)
+	}
+	if top, bottom := img.RGBAAt(50, 0), img.RGBAAt(50, 9); top != bottom {
+		t.Errorf("Expected columns of a horizontal gradient to be uniform, got %v and %v", top, bottom)
+	}
+}
+
+// TestCreateGradientBackground_RadialAndConic tests the center handling of radial and conic gradients.
+func TestCreateGradientBackground_RadialAndConic(t *testing.T) {
+	stops := []GradientStop{{Color: "#000000"}, {Color: "#FFFFFF"}}
+
+	radial := GradientConfig{Type: GradientRadial, CenterX: 0.5, CenterY: 0.5, Stops: stops}
+	img, err := createGradientBackground(&radial, 100, 100)
+	if err != nil {
+		t.Fatalf("createGradientBackground failed: %v", err)
+	}
+	if center, corner := img.RGBAAt(50, 50), img.RGBAAt(0, 0); center.R > 5 || corner.R < 245 {
+		t.Errorf("Expected dark center and light corner, got %v and %v", center, corner)
+	}
+
+	conic := GradientConfig{Type: GradientConic, CenterX: 0.5, CenterY: 0.5, Stops: stops}
+	img, err = createGradientBackground(&conic, 100, 100)
+	if err != nil {
+		t.Fatalf("createGradientBackground failed: %v", err)
+	}
+	right, bottom, left := img.RGBAAt(99, 50).R, img.RGBAAt(50, 99).R, img.RGBAAt(0, 50).R
+	if !(right < bottom && bottom < left) {
+		t.Errorf("Expected conic gradient to increase clockwise, got right=%d bottom=%d left=%d", right, bottom, left)
+	}
+}
+
+// TestCreateGradientBackground_Dither tests that dithering breaks up bands without shifting the average color.
+func TestCreateGradientBackground_Dither(t *testing.T) {
+	gradient := GradientConfig{
+		Type:  GradientLinear,
+		Angle: 90,
+		Stops: []GradientStop{{Color: "#404040"}, {Color: "#424242"}},
+	}
+
+	plain, err := createGradientBackground(&gradient, 64, 64)
+	if err != nil {
+		t.Fatalf("createGradientBackground failed: %v", err)
+	}
+	gradient.Dither = true
+	dithered, err := createGradientBackground(&gradient, 64, 64)
+	if err != nil {
+		t.Fatalf("createGradientBackground failed: %v", err)
+	}
+
+	average := func(img *image.RGBA) float64 {
+		sum := 0
+		for i := 0; i < len(img.Pix); i += 4 {
+			sum += int(img.Pix[i])
+		}
+		return float64(sum) / float64(len(img.Pix)/4)
+	}
+	if diff := math.Abs(average(plain) - average(dithered)); diff > 0.1 {
+		t.Errorf("Expected dithering to keep the average color, differed by %v", diff)
+	}
+
+	// Dithered columns mix neighbouring values instead of forming solid bands
+	mixed := false
+	for y := 1; y < 8; y++ {
+		if dithered.RGBAAt(20, y) != dithered.RGBAAt(20, 0) {
+			mixed = true
+		}
+	}
+	if !mixed {
+		t.Error("Expected dithered column to vary")
+	}
+}
+
+// TestCreateGradientBackground_InvalidType tests that unknown gradient types are rejected.
+func TestCreateGradientBackground_InvalidType(t *testing.T) {
+	gradient := GradientConfig{Type: "diamond", Stops: []GradientStop{{Color: "#000000"}}}
+	if _, err := createGradientBackground(&gradient, 10, 10); err == nil {
+		t.Error("Expected error for an unknown gradient type")
+	}
+}
+
+// TestDrawPattern tests the coverage of the generated patterns.
+func TestDrawPattern(t *testing.T) {
+	black := color.RGBA{A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	tests := []struct {
+		name    string
+		pattern PatternConfig
+		inked   image.Point
+		blank   image.Point
+	}{
+		{
+			name:    "dots",
+			pattern: PatternConfig{Type: PatternDots, Color: "#000000", Size: 20, Thickness: 6},
+			inked:   image.Point{X: 10, Y: 10},
+			blank:   image.Point{X: 0, Y: 0},
+		},
+		{
+			name:    "horizontal stripes",
+			pattern: PatternConfig{Type: PatternStripes, Color: "#000000", Size: 20, Thickness: 4, Angle: 0},
+			inked:   image.Point{X: 7, Y: 20},
+			blank:   image.Point{X: 7, Y: 10},
+		},
+		{
+			name:    "grid",
+			pattern: PatternConfig{Type: PatternGrid, Color: "#000000", Size: 20, Thickness: 2},
+			inked:   image.Point{X: 13, Y: 20},
+			blank:   image.Point{X: 10, Y: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := newFilledRGBA(40, 40, white)
+			if err := drawPattern(img, &tt.pattern); err != nil {
+				t.Fatalf("drawPattern failed: %v", err)
+			}
+			if got := img.RGBAAt(tt.inked.X, tt.inked.Y); got != black {
+				t.Errorf("Expected pattern at %v, got %v", tt.inked, got)
+			}
+			if got := img.RGBAAt(tt.blank.X, tt.blank.Y); got != white {
+				t.Errorf("Expected background at %v, got %v", tt.blank, got)
+			}
+		})
+	}
+
+	invalid := PatternConfig{Type: "waves", Color: "#000000", Size: 10}
+	if err := drawPattern(newFilledRGBA(4, 4, white), &invalid); err == nil {
+		t.Error("Expected error for an unknown pattern type")
+	}
+}
+
+// TestBackgroundProcessor_CreateBackground_GradientAndPattern tests that gradients and patterns are generated at canvas size.
+func TestBackgroundProcessor_CreateBackground_GradientAndPattern(t *testing.T) {
+	processor := NewBackgroundProcessor("/test")
+	config := getDefaultConfig()
+	config.Background.Gradient.Type = GradientLinear
+	config.Background.Gradient.Stops = []GradientStop{{Color: "#FF0000"}, {Color: "#0000FF"}}
+	config.Background.Pattern.Type = PatternGrid
+
+	background, err := processor.CreateBackground(config, "")
+	if err != nil {
+		t.Fatalf("CreateBackground failed: %v", err)
+	}
+	if bounds := background.Bounds(); bounds.Dx() != DefaultImageWidth || bounds.Dy() != DefaultImageHeight {
+		t.Errorf("Expected %dx%d background, got %v", DefaultImageWidth, DefaultImageHeight, bounds)
+	}
+
+	r, _, b, _ := background.At(DefaultImageWidth/2+5, 5).RGBA()
+	if r == 0 || b == 0 {
+		t.Errorf("Expected gradient midpoint to mix red and blue, got r=%d b=%d", r, b)
+	}
+
+	config.Background.Gradient.Stops = nil
+	if _, err := processor.CreateBackground(config, ""); err == nil {
+		t.Error("Expected error for a gradient without stops")
+	}
+}
+
+// TestMergeConfigsWithSettings_BackgroundGradient tests the merge of gradient and pattern settings.
+func TestMergeConfigsWithSettings_BackgroundGradient(t *testing.T) {
+	global := &ConfigSettings{
+		Background: &BackgroundSettings{
+			Gradient: &GradientSettings{
+				Type:  stringPtr(GradientLinear),
+				Angle: float64Ptr(45),
+				Stops: []GradientStop{{Color: "#000000"}, {Color: "#FFFFFF"}},
+			},
+			Pattern: &PatternSettings{Type: stringPtr(PatternDots)},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Background: &BackgroundOverride{
+			Gradient: &GradientSettings{
+				Type:  stringPtr(GradientRadial),
+				Stops: []GradientStop{{Color: "#FF0000"}},
+			},
+			Pattern: &PatternSettings{Size: intPtr(40)},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	gradient := config.Background.Gradient
+	if gradient.Type != GradientRadial || gradient.Angle != 45 {
+		t.Errorf("Expected radial gradient keeping angle 45, got %s %v", gradient.Type, gradient.Angle)
+	}
+	if len(gradient.Stops) != 1 || gradient.Stops[0].Color != "#FF0000" {
+		t.Errorf("Expected front matter stops to replace inherited stops, got %+v", gradient.Stops)
+	}
+	if !gradient.Dither {
+		t.Error("Expected dithering to stay enabled by default")
+	}
+
+	pattern := config.Background.Pattern
+	if pattern.Type != PatternDots || pattern.Size != 40 || pattern.Color != DefaultPatternColor {
+		t.Errorf("Expected merged dots pattern of size 40, got %+v", pattern)
+	}
+	if len(global.Background.Gradient.Stops) != 2 {
+		t.Error("Expected global settings to remain unchanged")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// drawPattern draws a repeating pattern over img with anti-aliased edges.
+// Stripe angles are measured clockwise from horizontal, so 0 draws horizontal stripes.
+func drawPattern(img *image.RGBA, pattern *PatternConfig) error {
+	c, err := parseHexColor(pattern.Color)
+	if err != nil {
+		return NewValidationError(fmt.Sprintf("invalid pattern color '%s': %v", pattern.Color, err))
+	}
+	if pattern.Size <= 0 {
+		return NewValidationError(fmt.Sprintf("invalid pattern size: %d (must be positive)", pattern.Size))
+	}
+
+	size := float64(pattern.Size)
+	half := pattern.Thickness / 2
+
+	// Coverage of a feature at distance d from its center line or point
+	coverageAt := func(d float64) float64 {
+		return clampUnit(half - d + 0.5)
+	}
+	// Distance from v to the nearest multiple of size
+	distanceToLine := func(v float64) float64 {
+		return math.Abs(v - size*math.Round(v/size))
+	}
+
+	var coverage func(x, y float64) float64
+	switch pattern.Type {
+	case PatternDots:
+		coverage = func(x, y float64) float64 {
+			dx := distanceToLine(x - size/2)
+			dy := distanceToLine(y - size/2)
+			return coverageAt(math.Hypot(dx, dy))
+		}
+	case PatternStripes:
+		angle := pattern.Angle * math.Pi / 180
+		nx, ny := -math.Sin(angle), math.Cos(angle)
+		coverage = func(x, y float64) float64 {
+			return coverageAt(distanceToLine(x*nx + y*ny))
+		}
+	case PatternGrid:
+		coverage = func(x, y float64) float64 {
+			return math.Max(coverageAt(distanceToLine(x)), coverageAt(distanceToLine(y)))
+		}
+	default:
+		return NewValidationError(fmt.Sprintf("unknown pattern type: %s", pattern.Type))
+	}
+
+	bounds := img.Bounds()
+	mask := image.NewAlpha(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			mask.SetAlpha(x, y, alphaFromUnit(coverage(float64(x-bounds.Min.X)+0.5, float64(y-bounds.Min.Y)+0.5)))
+		}
+	}
+
+	src := image.NewUniform(color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A})
+	draw.DrawMask(img, bounds, src, image.Point{}, mask, bounds.Min, draw.Over)
+	return nil
+}
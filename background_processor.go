@@ -23,13 +23,87 @@ func NewBackgroundProcessor(configDir string) *BackgroundProcessor {
 	}
 }
 
-// CreateBackground creates a background image from either an image file or solid color.
+// CreateBackground creates a background image from an image file, a generative background,
+// a gradient or a solid color,
+// applies the filter chain and draws the configured pattern over it.
 func (bp *BackgroundProcessor) CreateBackground(config *Config, articlePath string) (image.Image, error) {
-	if config.Background.Image != nil && *config.Background.Image != "" {
-		return bp.loadBackgroundImage(*config.Background.Image, articlePath)
+	var background image.Image
+	var err error
+
+	switch {
+	case config.Background.Image != nil && *config.Background.Image != "":
+		background, err = bp.loadBackgroundImage(*config.Background.Image, articlePath)
+		if err == nil {
+			background, err = bp.fitBackgroundImage(background, &config.Background)
+		}
+	case config.Background.Generative.Type != "":
+		background, err = bp.createGenerativeBackground(config.Background.Color, &config.Background.Generative)
+	case config.Background.Gradient.Type != "":
+		background, err = bp.createGradientBackground(config.Background.Color, &config.Background.Gradient)
+	default:
+		background, err = bp.createColorBackground(config.Background.Color)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	background, err = applyBackgroundFilters(background, config.Background.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Background.Pattern.Type != "" {
+		return bp.applyPattern(background, &config.Background.Pattern)
+	}
+
+	return background, nil
+}
+
+// createGradientBackground creates a gradient background (1200x630 pixels).
+// The gradient is drawn over the background color, which shows through translucent stops.
+func (bp *BackgroundProcessor) createGradientBackground(colorHex string, gradient *GradientConfig) (image.Image, error) {
+	base, err := bp.createColorBackground(colorHex)
+	if err != nil {
+		return nil, err
+	}
+
+	gradientImage, err := createGradientBackground(gradient, DefaultImageWidth, DefaultImageHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	backgroundImage := base.(*image.RGBA)
+	draw.Draw(backgroundImage, backgroundImage.Bounds(), gradientImage, image.Point{}, draw.Over)
+	return backgroundImage, nil
+}
+
+// createGenerativeBackground creates a generative background (1200x630 pixels) over the background color.
+func (bp *BackgroundProcessor) createGenerativeBackground(colorHex string, generative *GenerativeConfig) (image.Image, error) {
+	base, err := bp.createColorBackground(colorHex)
+	if err != nil {
+		return nil, err
 	}
 
-	return bp.createColorBackground(config.Background.Color)
+	generated, err := createGenerativeBackground(generative, DefaultImageWidth, DefaultImageHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	backgroundImage := base.(*image.RGBA)
+	draw.Draw(backgroundImage, backgroundImage.Bounds(), generated, image.Point{}, draw.Over)
+	return backgroundImage, nil
+}
+
+// applyPattern draws the pattern over a copy of the background.
+func (bp *BackgroundProcessor) applyPattern(background image.Image, pattern *PatternConfig) (image.Image, error) {
+	bounds := background.Bounds()
+	patterned := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(patterned, patterned.Bounds(), background, bounds.Min, draw.Src)
+
+	if err := drawPattern(patterned, pattern); err != nil {
+		return nil, err
+	}
+	return patterned, nil
 }
 
 // loadBackgroundImage loads a background image from the filesystem.
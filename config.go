@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -19,18 +20,41 @@ type TextConfig struct {
 	// Content configuration
 	Content *string `yaml:"content"` // Content template (nil means use template)
 	// Font configuration
-	Font *string `yaml:"font"` // Path to font file (nil means auto-detect)
-	Size float64 `yaml:"size"` // Font size
+	Font   *string `yaml:"font"`   // Path to font file (nil means auto-detect)
+	Size   float64 `yaml:"size"`   // Font size
+	Weight int     `yaml:"weight"` // Font weight (100-900, 400 is regular)
+	Width  float64 `yaml:"width"`  // Font width in percent (100 is normal)
+	Italic bool    `yaml:"italic"` // Whether to render italic text
 	// Text color configuration
-	Color string `yaml:"color"` // Hex color code
+	Color string         `yaml:"color"` // Hex color code
+	Fill  TextFillConfig `yaml:"fill"`  // Gradient or image texture painted into the glyphs instead of the color
 	// Text rendering area coordinates
 	Area          TextArea `yaml:"area"`
 	BlockPosition string   `yaml:"block_position"` // Text block position in area
 	LineAlignment string   `yaml:"line_alignment"` // Individual line alignment within block
-	Overflow      string   `yaml:"overflow"`       // Overflow handling ("shrink" or "clip")
+	Overflow      string   `yaml:"overflow"`       // Overflow handling ("shrink", "clip", "ellipsis", "shrink-then-ellipsis" or "fit")
 	MinSize       float64  `yaml:"min_size"`       // Minimum font size for shrink mode
+	MaxSize       float64  `yaml:"max_size"`       // Maximum font size for fit mode
+	MaxLines      int      `yaml:"max_lines"`      // Maximum number of lines (0 for no limit)
+	Ellipsis      string   `yaml:"ellipsis"`       // Text appended to truncated text in ellipsis modes
+	Wrap          string   `yaml:"wrap"`           // Line wrapping ("greedy" or "balanced")
 	LineHeight    float64  `yaml:"line_height"`    // Line height multiplier
 	LetterSpacing int      `yaml:"letter_spacing"` // Letter spacing in pixels
+	Kerning       bool     `yaml:"kerning"`        // Whether to apply font kerning pairs
+	// Text effects drawn behind the glyphs
+	Stroke TextStrokeConfig `yaml:"stroke"` // Outline around the glyphs
+	Shadow TextShadowConfig `yaml:"shadow"` // Drop shadow
+	Glow   TextGlowConfig   `yaml:"glow"`   // Soft glow around the glyphs
+	// Marker box drawn behind each line
+	LineBackground TextLineBackgroundConfig `yaml:"line_background"`
+	// Card drawn behind the whole text block
+	Panel TextPanelConfig `yaml:"panel"`
+	// Inline markup for styled runs within the text
+	Markup TextMarkupConfig `yaml:"markup"`
+	// Rules that style matches of patterns or words within the text
+	Highlights []TextHighlightConfig `yaml:"highlights"`
+	// Stacking order among background, overlays and text (higher values are drawn on top)
+	Z int `yaml:"z"`
 	// Japanese line breaking rules configuration
 	LineBreaking LineBreakingConfig `yaml:"line_breaking"`
 }
@@ -60,6 +84,11 @@ func (c MainOverlayConfig) GetOpacity() *float64 {
 	return &c.Opacity
 }
 
+// GetBlend implements OverlaySettings interface
+func (c MainOverlayConfig) GetBlend() *string {
+	return &c.Blend
+}
+
 // GetImage implements OverlaySettings interface
 func (f *ArticleOverlayConfig) GetImage() *string {
 	return f.Image
@@ -102,6 +131,11 @@ func (f *ArticleOverlayConfig) GetOpacity() *float64 {
 	return f.Opacity
 }
 
+// GetBlend implements OverlaySettings interface
+func (f *ArticleOverlayConfig) GetBlend() *string {
+	return f.Blend
+}
+
 // Config represents the main configuration structure for OGP image generation.
 // It contains all settings for fonts, text rendering, image processing, and line breaking.
 type Config struct {
@@ -115,8 +149,20 @@ type Config struct {
 	Title       TextConfig `yaml:"title"`       // Title text configuration
 	Description TextConfig `yaml:"description"` // Description text configuration
 
+	// Additional named text elements (site name, author, date, ...)
+	Texts map[string]TextConfig `yaml:"texts"`
+
+	// Tag chips rendered from the article tags
+	Tags TagsConfig `yaml:"tags"`
+
+	// Vector shapes (accent bars, panels, dividers, ...)
+	Shapes []ShapeConfig `yaml:"shapes"`
+
 	// Default overlay configuration
 	Overlay MainOverlayConfig `yaml:"overlay"`
+
+	// Additional overlays (site logo, author avatar, badges, ...)
+	Overlays []MainOverlayConfig `yaml:"overlays"`
 }
 
 // parseHexColor parses hex color codes like "#FF00FF" or "#ff00ff80"
@@ -158,19 +204,36 @@ func parseHexColor(hex string) (color.RGBA, error) {
 // TextConfigOverride represents overrides for a text configuration in front matter.
 // All fields are optional pointers to allow partial overrides.
 type TextConfigOverride struct {
-	Visible       *bool                 `yaml:"visible,omitempty"`
-	Content       *string               `yaml:"content,omitempty"`
-	Font          *string               `yaml:"font,omitempty"`
-	Size          *float64              `yaml:"size,omitempty"`
-	Color         *string               `yaml:"color,omitempty"` // Hex color code
-	Area          *TextAreaConfig       `yaml:"area,omitempty"`
-	BlockPosition *string               `yaml:"block_position,omitempty"`
-	LineAlignment *string               `yaml:"line_alignment,omitempty"`
-	Overflow      *string               `yaml:"overflow,omitempty"`
-	MinSize       *float64              `yaml:"min_size,omitempty"`
-	LineHeight    *float64              `yaml:"line_height,omitempty"`
-	LetterSpacing *int                  `yaml:"letter_spacing,omitempty"`
-	LineBreaking  *LineBreakingOverride `yaml:"line_breaking,omitempty"`
+	Visible        *bool                       `yaml:"visible,omitempty"`
+	Content        *string                     `yaml:"content,omitempty"`
+	Font           *string                     `yaml:"font,omitempty"`
+	Size           *float64                    `yaml:"size,omitempty"`
+	Weight         *int                        `yaml:"weight,omitempty"`
+	Width          *float64                    `yaml:"width,omitempty"`
+	Italic         *bool                       `yaml:"italic,omitempty"`
+	Color          *string                     `yaml:"color,omitempty"` // Hex color code
+	Fill           *TextFillSettings           `yaml:"fill,omitempty"`
+	Area           *TextAreaConfig             `yaml:"area,omitempty"`
+	BlockPosition  *string                     `yaml:"block_position,omitempty"`
+	LineAlignment  *string                     `yaml:"line_alignment,omitempty"`
+	Overflow       *string                     `yaml:"overflow,omitempty"`
+	MinSize        *float64                    `yaml:"min_size,omitempty"`
+	MaxSize        *float64                    `yaml:"max_size,omitempty"`
+	MaxLines       *int                        `yaml:"max_lines,omitempty"`
+	Ellipsis       *string                     `yaml:"ellipsis,omitempty"`
+	Wrap           *string                     `yaml:"wrap,omitempty"`
+	LineHeight     *float64                    `yaml:"line_height,omitempty"`
+	LetterSpacing  *int                        `yaml:"letter_spacing,omitempty"`
+	Kerning        *bool                       `yaml:"kerning,omitempty"`
+	Stroke         *TextStrokeSettings         `yaml:"stroke,omitempty"`
+	Shadow         *TextShadowSettings         `yaml:"shadow,omitempty"`
+	Glow           *TextGlowSettings           `yaml:"glow,omitempty"`
+	LineBackground *TextLineBackgroundSettings `yaml:"line_background,omitempty"`
+	Panel          *TextPanelSettings          `yaml:"panel,omitempty"`
+	Markup         *TextMarkupSettings         `yaml:"markup,omitempty"`
+	Highlights     []TextHighlightConfig       `yaml:"highlights,omitempty"`
+	Z              *int                        `yaml:"z,omitempty"`
+	LineBreaking   *LineBreakingOverride       `yaml:"line_breaking,omitempty"`
 }
 
 // OGPFrontMatter represents OGP-specific settings in article front matter.
@@ -180,12 +243,24 @@ type OGPFrontMatter struct {
 	Title       *TextConfigOverride `yaml:"title,omitempty"`       // Title text overrides
 	Description *TextConfigOverride `yaml:"description,omitempty"` // Description text overrides
 
+	// Named text element overrides, merged by name
+	Texts map[string]*TextConfigOverride `yaml:"texts,omitempty"`
+
+	// Tag chips overrides
+	Tags *TagsSettings `yaml:"tags,omitempty"`
+
+	// Shape overrides, merged by id with the configured shapes
+	Shapes []ShapeSettings `yaml:"shapes,omitempty"`
+
 	// Background settings
 	Background *BackgroundOverride `yaml:"background,omitempty"`
 
 	// Overlay image composition settings
 	Overlay *ArticleOverlayConfig `yaml:"overlay,omitempty"`
 
+	// Additional overlays, merged by id with the configured overlays
+	Overlays []ArticleOverlayConfig `yaml:"overlays,omitempty"`
+
 	// Output settings
 	Output *OutputOverride `yaml:"output,omitempty"`
 }
@@ -198,6 +273,7 @@ func getDefaultConfig() *Config {
 	setDefaultOutput(config)
 	setDefaultTitle(config)
 	setDefaultDescription(config)
+	setDefaultTags(config)
 	setDefaultOverlay(config)
 
 	return config
@@ -205,7 +281,22 @@ func getDefaultConfig() *Config {
 
 // setDefaultBackground configures default background settings
 func setDefaultBackground(config *Config) {
+	config.Background.Source = DefaultBackgroundSource
+	config.Background.Cover.Fields = append([]string{}, DefaultCoverFields...)
+	config.Background.Cover.Globs = append([]string{}, DefaultCoverGlobs...)
 	config.Background.Color = DefaultBackgroundColor
+	config.Background.Fit = DefaultBackgroundFit
+	config.Background.FocalPoint = FocalPointConfig{X: DefaultFocalPoint, Y: DefaultFocalPoint}
+	config.Background.Generative.Palette = append([]string{}, DefaultGenerativePalette...)
+	config.Background.Gradient.Angle = DefaultGradientAngle
+	config.Background.Gradient.CenterX = DefaultGradientCenter
+	config.Background.Gradient.CenterY = DefaultGradientCenter
+	config.Background.Gradient.Dither = DefaultGradientDither
+	config.Background.Pattern.Color = DefaultPatternColor
+	config.Background.Pattern.Size = DefaultPatternSize
+	config.Background.Pattern.Thickness = DefaultPatternThickness
+	config.Background.Pattern.Angle = DefaultPatternAngle
+	config.Background.Z = DefaultBackgroundZ
 }
 
 // setDefaultOutput configures default output settings
@@ -220,7 +311,11 @@ func setDefaultTitle(config *Config) {
 	config.Title.Visible = true
 	config.Title.Font = nil
 	config.Title.Size = DefaultTitleFontSize
+	config.Title.Weight = DefaultFontWeight
+	config.Title.Width = DefaultFontWidth
+	config.Title.Italic = false
 	config.Title.Color = DefaultTitleColor
+	setDefaultTextFill(&config.Title.Fill)
 	config.Title.Area.X = DefaultTitleAreaX
 	config.Title.Area.Y = DefaultTitleAreaY
 	config.Title.Area.Width = DefaultTitleAreaWidth
@@ -229,18 +324,70 @@ func setDefaultTitle(config *Config) {
 	config.Title.LineAlignment = DefaultTitleLineAlignment
 	config.Title.Overflow = OverflowShrink
 	config.Title.MinSize = DefaultTitleMinSize
+	config.Title.MaxSize = DefaultTitleMaxSize
+	config.Title.MaxLines = 0
+	config.Title.Ellipsis = DefaultEllipsis
+	config.Title.Wrap = WrapGreedy
 	config.Title.LineHeight = DefaultLineHeight
 	config.Title.LetterSpacing = DefaultTitleLetterSpacing
+	config.Title.Kerning = DefaultKerning
+	config.Title.Stroke.Color = DefaultTextStrokeColor
+	config.Title.Shadow.Color = DefaultTextShadowColor
+	config.Title.Glow.Color = DefaultTextGlowColor
+	setDefaultLineBackground(&config.Title.LineBackground)
+	setDefaultPanel(&config.Title.Panel)
+	setDefaultMarkup(&config.Title.Markup)
+	config.Title.Z = DefaultTextZ
 	config.Title.LineBreaking.StartProhibited = DefaultStartProhibitedChars
 	config.Title.LineBreaking.EndProhibited = DefaultEndProhibitedChars
 }
 
+// setDefaultTextFill configures default text fill settings.
+// The gradient defaults match the background gradient defaults.
+func setDefaultTextFill(fill *TextFillConfig) {
+	fill.Gradient.Angle = DefaultGradientAngle
+	fill.Gradient.CenterX = DefaultGradientCenter
+	fill.Gradient.CenterY = DefaultGradientCenter
+	fill.Gradient.Dither = DefaultGradientDither
+	fill.Relative = TextFillRelativeText
+}
+
+// setDefaultLineBackground configures default line background settings.
+// No color is set, so line backgrounds are disabled until a color is configured.
+func setDefaultLineBackground(background *TextLineBackgroundConfig) {
+	background.PaddingX = DefaultLineBackgroundPaddingX
+	background.PaddingY = DefaultLineBackgroundPaddingY
+	background.Opacity = DefaultLineBackgroundOpacity
+}
+
+// setDefaultPanel configures default text panel settings.
+// No color or border is set, so panels are disabled until one is configured.
+func setDefaultPanel(panel *TextPanelConfig) {
+	panel.PaddingX = DefaultPanelPaddingX
+	panel.PaddingY = DefaultPanelPaddingY
+	panel.Radius = DefaultPanelRadius
+	panel.BorderColor = DefaultPanelBorderColor
+	panel.Shadow.Color = DefaultTextShadowColor
+}
+
+// setDefaultMarkup configures default inline markup settings.
+// Markup is disabled by default, so asterisks and backquotes in existing titles are drawn as is.
+func setDefaultMarkup(markup *TextMarkupConfig) {
+	markup.Enabled = false
+	markup.Code.Background = DefaultMarkupCodeBackground
+	markup.Highlight.Background = DefaultMarkupHighlightBackground
+}
+
 // setDefaultDescription configures default description settings
 func setDefaultDescription(config *Config) {
 	config.Description.Visible = false
 	config.Description.Font = nil
 	config.Description.Size = DefaultDescriptionFontSize
+	config.Description.Weight = DefaultFontWeight
+	config.Description.Width = DefaultFontWidth
+	config.Description.Italic = false
 	config.Description.Color = DefaultDescriptionColor
+	setDefaultTextFill(&config.Description.Fill)
 	config.Description.Area.X = DefaultDescriptionAreaX
 	config.Description.Area.Y = DefaultDescriptionAreaY
 	config.Description.Area.Width = DefaultDescriptionAreaWidth
@@ -249,12 +396,83 @@ func setDefaultDescription(config *Config) {
 	config.Description.LineAlignment = DefaultDescriptionLineAlignment
 	config.Description.Overflow = OverflowClip
 	config.Description.MinSize = DefaultDescriptionMinSize
+	config.Description.MaxSize = DefaultDescriptionMaxSize
+	config.Description.MaxLines = 0
+	config.Description.Ellipsis = DefaultEllipsis
+	config.Description.Wrap = WrapGreedy
 	config.Description.LineHeight = DefaultLineHeight
 	config.Description.LetterSpacing = DefaultDescriptionLetterSpacing
+	config.Description.Kerning = DefaultKerning
+	config.Description.Stroke.Color = DefaultTextStrokeColor
+	config.Description.Shadow.Color = DefaultTextShadowColor
+	config.Description.Glow.Color = DefaultTextGlowColor
+	setDefaultLineBackground(&config.Description.LineBackground)
+	setDefaultPanel(&config.Description.Panel)
+	setDefaultMarkup(&config.Description.Markup)
+	config.Description.Z = DefaultTextZ
 	config.Description.LineBreaking.StartProhibited = DefaultStartProhibitedChars
 	config.Description.LineBreaking.EndProhibited = DefaultEndProhibitedChars
 }
 
+// setDefaultTags configures default tag chips settings
+func setDefaultTags(config *Config) {
+	config.Tags.Visible = DefaultTagsVisible
+	config.Tags.Font = nil
+	config.Tags.Size = DefaultTagFontSize
+	config.Tags.Weight = DefaultFontWeight
+	config.Tags.Color = DefaultTagColor
+	config.Tags.Background = DefaultTagBackground
+	config.Tags.Area.X = DefaultTagsAreaX
+	config.Tags.Area.Y = DefaultTagsAreaY
+	config.Tags.Area.Width = DefaultTagsAreaWidth
+	config.Tags.Area.Height = DefaultTagsAreaHeight
+	config.Tags.LineAlignment = DefaultTagsLineAlignment
+	config.Tags.PaddingX = DefaultTagPaddingX
+	config.Tags.PaddingY = DefaultTagPaddingY
+	config.Tags.Radius = DefaultTagRadius
+	config.Tags.Gap = DefaultTagGap
+	config.Tags.Max = 0
+	config.Tags.ShowMore = DefaultTagsShowMore
+	config.Tags.Z = DefaultTextZ
+}
+
+// newShapeEntry returns the defaults for an entry of the shapes list.
+// Like SVG, shapes are filled black and have no stroke unless configured.
+func newShapeEntry(id string) ShapeConfig {
+	return ShapeConfig{
+		ID:          id,
+		Visible:     true,
+		Type:        ShapeRect,
+		Fill:        DefaultShapeFill,
+		StrokeWidth: DefaultShapeStrokeWidth,
+		Opacity:     DefaultShapeOpacity,
+		Z:           DefaultShapeZ,
+	}
+}
+
+// newTextElementConfig returns the defaults for a named text element.
+// Text elements start from the description defaults but are visible and,
+// without an explicit area, use the whole image minus the default padding.
+func newTextElementConfig() TextConfig {
+	config := &Config{}
+	setDefaultDescription(config)
+
+	element := config.Description
+	element.Visible = true
+	element.Area = TextArea{}
+	return element
+}
+
+// sortedTextElementNames returns the names of the text elements in lexical order.
+func sortedTextElementNames(texts map[string]TextConfig) []string {
+	names := make([]string, 0, len(texts))
+	for name := range texts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // setDefaultOverlay configures default overlay settings
 func setDefaultOverlay(config *Config) {
 	config.Overlay.Visible = DefaultOverlayVisible
@@ -262,6 +480,39 @@ func setDefaultOverlay(config *Config) {
 	config.Overlay.Placement.Y = DefaultOverlayY
 	config.Overlay.Fit = DefaultOverlayFit
 	config.Overlay.Opacity = DefaultOverlayOpacity
+	config.Overlay.Blend = DefaultOverlayBlend
+	config.Overlay.Z = DefaultOverlayZ
+}
+
+// newOverlayListEntry returns the defaults for an entry of the overlays list.
+// Unlike the single overlay, list entries are visible unless disabled explicitly.
+func newOverlayListEntry(id string) MainOverlayConfig {
+	return MainOverlayConfig{
+		ID:      id,
+		Visible: true,
+		Placement: PlacementConfig{
+			X: DefaultOverlayX,
+			Y: DefaultOverlayY,
+		},
+		Fit:     DefaultOverlayFit,
+		Opacity: DefaultOverlayOpacity,
+		Blend:   DefaultOverlayBlend,
+		Z:       DefaultOverlayZ,
+	}
+}
+
+// visibleOverlays returns all overlays that should be rendered: the single overlay
+// first, followed by list entries in their configured order.
+func visibleOverlays(config *Config) []MainOverlayConfig {
+	var overlays []MainOverlayConfig
+	for _, overlay := range append([]MainOverlayConfig{config.Overlay}, config.Overlays...) {
+		if !overlay.Visible || overlay.Image == nil || *overlay.Image == "" {
+			continue
+		}
+		overlays = append(overlays, overlay)
+	}
+
+	return overlays
 }
 
 // loadConfig reads and parses a YAML configuration file.
@@ -35,20 +35,196 @@ func (cm *ConfigMerger) applySettingsToConfig(target *Config, settings *ConfigSe
 		cm.applyTextSettings(&target.Description, settings.Description)
 	}
 
+	// Apply named text element settings
+	for name, textSettings := range settings.Texts {
+		element := cm.textElement(target, name)
+		if textSettings != nil {
+			cm.applyTextSettings(&element, textSettings)
+		}
+		target.Texts[name] = element
+	}
+
+	// Apply tag chips settings
+	if settings.Tags != nil {
+		cm.applyTagsSettings(&target.Tags, settings.Tags)
+	}
+
+	// Apply shape list settings
+	for i := range settings.Shapes {
+		shape := cm.findOrAppendShape(&target.Shapes, settings.Shapes[i].ID)
+		cm.applyShapeSettings(shape, &settings.Shapes[i])
+	}
+
 	// Apply overlay settings
 	if settings.Overlay != nil {
 		cm.applyOverlaySettings(&target.Overlay, settings.Overlay)
 	}
+
+	// Apply overlay list settings
+	if len(settings.Overlays) > 0 {
+		cm.applyOverlayListSettings(&target.Overlays, settings.Overlays)
+	}
 }
 
 // applyBackgroundSettings applies BackgroundSettings to BackgroundConfig.
 func (cm *ConfigMerger) applyBackgroundSettings(target *BackgroundConfig, settings *BackgroundSettings) {
+	if settings.Source != nil {
+		target.Source = *settings.Source
+	}
+	if settings.Cover != nil {
+		cm.applyCoverSourceSettings(&target.Cover, settings.Cover)
+	}
 	if settings.Image != nil {
 		target.Image = cm.copyStringPtr(settings.Image)
 	}
 	if settings.Color != nil {
 		target.Color = *settings.Color
 	}
+	if settings.Fit != nil {
+		target.Fit = *settings.Fit
+	}
+	if settings.FocalPoint != nil {
+		cm.applyFocalPointSettings(&target.FocalPoint, settings.FocalPoint)
+	}
+	if settings.Filters != nil {
+		target.Filters = cm.copyBackgroundFilters(settings.Filters)
+	}
+	if settings.Generative != nil {
+		cm.applyGenerativeSettings(&target.Generative, settings.Generative)
+	}
+	if settings.Gradient != nil {
+		cm.applyGradientSettings(&target.Gradient, settings.Gradient)
+	}
+	if settings.Pattern != nil {
+		cm.applyPatternSettings(&target.Pattern, settings.Pattern)
+	}
+	if settings.Z != nil {
+		target.Z = *settings.Z
+	}
+}
+
+// applyCoverSourceSettings applies CoverSourceSettings to CoverSourceConfig.
+func (cm *ConfigMerger) applyCoverSourceSettings(target *CoverSourceConfig, settings *CoverSourceSettings) {
+	if settings.Fields != nil {
+		target.Fields = cm.copyStrings(settings.Fields)
+	}
+	if settings.Globs != nil {
+		target.Globs = cm.copyStrings(settings.Globs)
+	}
+}
+
+// applyFocalPointSettings applies FocalPointSettings to FocalPointConfig.
+func (cm *ConfigMerger) applyFocalPointSettings(target *FocalPointConfig, settings *FocalPointSettings) {
+	if settings.X != nil {
+		target.X = *settings.X
+	}
+	if settings.Y != nil {
+		target.Y = *settings.Y
+	}
+}
+
+// applyGenerativeSettings applies GenerativeSettings to GenerativeConfig.
+func (cm *ConfigMerger) applyGenerativeSettings(target *GenerativeConfig, settings *GenerativeSettings) {
+	if settings.Type != nil {
+		target.Type = *settings.Type
+	}
+	if settings.Seed != nil {
+		target.Seed = *settings.Seed
+	}
+	if settings.Palette != nil {
+		target.Palette = cm.copyStrings(settings.Palette)
+	}
+	if settings.Count != nil {
+		target.Count = *settings.Count
+	}
+}
+
+// applyGradientSettings applies GradientSettings to GradientConfig.
+func (cm *ConfigMerger) applyGradientSettings(target *GradientConfig, settings *GradientSettings) {
+	if settings.Type != nil {
+		target.Type = *settings.Type
+	}
+	if settings.Angle != nil {
+		target.Angle = *settings.Angle
+	}
+	if settings.Stops != nil {
+		target.Stops = cm.copyGradientStops(settings.Stops)
+	}
+	if settings.CenterX != nil {
+		target.CenterX = *settings.CenterX
+	}
+	if settings.CenterY != nil {
+		target.CenterY = *settings.CenterY
+	}
+	if settings.Dither != nil {
+		target.Dither = *settings.Dither
+	}
+}
+
+// applyPatternSettings applies PatternSettings to PatternConfig.
+func (cm *ConfigMerger) applyPatternSettings(target *PatternConfig, settings *PatternSettings) {
+	if settings.Type != nil {
+		target.Type = *settings.Type
+	}
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+	if settings.Size != nil {
+		target.Size = *settings.Size
+	}
+	if settings.Thickness != nil {
+		target.Thickness = *settings.Thickness
+	}
+	if settings.Angle != nil {
+		target.Angle = *settings.Angle
+	}
+}
+
+// copyBackgroundFilters creates a deep copy of background filters
+func (cm *ConfigMerger) copyBackgroundFilters(src []BackgroundFilter) []BackgroundFilter {
+	if src == nil {
+		return nil
+	}
+
+	filters := make([]BackgroundFilter, len(src))
+	for i, filter := range src {
+		filters[i] = filter
+		filters[i].Amount = cm.copyFloat64Ptr(filter.Amount)
+		filters[i].Opacity = cm.copyFloat64Ptr(filter.Opacity)
+	}
+	return filters
+}
+
+// copyHighlights creates a deep copy of highlight rules
+func (cm *ConfigMerger) copyHighlights(src []TextHighlightConfig) []TextHighlightConfig {
+	if src == nil {
+		return nil
+	}
+
+	highlights := make([]TextHighlightConfig, len(src))
+	for i, highlight := range src {
+		highlight.Words = cm.copyStrings(highlight.Words)
+		highlight.Font = cm.copyStringPtr(highlight.Font)
+		highlights[i] = highlight
+	}
+	return highlights
+}
+
+// copyGradientStops creates a deep copy of gradient stops
+func (cm *ConfigMerger) copyGradientStops(src []GradientStop) []GradientStop {
+	if src == nil {
+		return nil
+	}
+
+	stops := make([]GradientStop, len(src))
+	for i, stop := range src {
+		stops[i] = GradientStop{Color: stop.Color}
+		if stop.Position != nil {
+			position := *stop.Position
+			stops[i].Position = &position
+		}
+	}
+	return stops
 }
 
 // applyOutputSettings applies OutputSettings to OutputConfig.
@@ -78,6 +254,15 @@ func (cm *ConfigMerger) applyTextSettings(target *TextConfig, settings *TextSett
 	if settings.Size != nil {
 		target.Size = *settings.Size
 	}
+	if settings.Weight != nil {
+		target.Weight = *settings.Weight
+	}
+	if settings.Width != nil {
+		target.Width = *settings.Width
+	}
+	if settings.Italic != nil {
+		target.Italic = *settings.Italic
+	}
 	if settings.Color != nil {
 		target.Color = *settings.Color
 	}
@@ -96,17 +281,196 @@ func (cm *ConfigMerger) applyTextSettings(target *TextConfig, settings *TextSett
 	if settings.MinSize != nil {
 		target.MinSize = *settings.MinSize
 	}
+	if settings.MaxSize != nil {
+		target.MaxSize = *settings.MaxSize
+	}
+	if settings.MaxLines != nil {
+		target.MaxLines = *settings.MaxLines
+	}
+	if settings.Ellipsis != nil {
+		target.Ellipsis = *settings.Ellipsis
+	}
+	if settings.Wrap != nil {
+		target.Wrap = *settings.Wrap
+	}
 	if settings.LineHeight != nil {
 		target.LineHeight = *settings.LineHeight
 	}
 	if settings.LetterSpacing != nil {
 		target.LetterSpacing = *settings.LetterSpacing
 	}
+	if settings.Kerning != nil {
+		target.Kerning = *settings.Kerning
+	}
+	if settings.Fill != nil {
+		cm.applyTextFillSettings(&target.Fill, settings.Fill)
+	}
+	if settings.Stroke != nil {
+		cm.applyTextStrokeSettings(&target.Stroke, settings.Stroke)
+	}
+	if settings.Shadow != nil {
+		cm.applyTextShadowSettings(&target.Shadow, settings.Shadow)
+	}
+	if settings.Glow != nil {
+		cm.applyTextGlowSettings(&target.Glow, settings.Glow)
+	}
+	if settings.LineBackground != nil {
+		cm.applyLineBackgroundSettings(&target.LineBackground, settings.LineBackground)
+	}
+	if settings.Panel != nil {
+		cm.applyPanelSettings(&target.Panel, settings.Panel)
+	}
+	if settings.Markup != nil {
+		cm.applyMarkupSettings(&target.Markup, settings.Markup)
+	}
+	if settings.Highlights != nil {
+		target.Highlights = cm.copyHighlights(settings.Highlights)
+	}
+	if settings.Z != nil {
+		target.Z = *settings.Z
+	}
 	if settings.LineBreaking != nil {
 		cm.applyLineBreakingSettings(&target.LineBreaking, settings.LineBreaking)
 	}
 }
 
+// applyTextFillSettings applies TextFillSettings to TextFillConfig.
+func (cm *ConfigMerger) applyTextFillSettings(target *TextFillConfig, settings *TextFillSettings) {
+	if settings.Gradient != nil {
+		cm.applyGradientSettings(&target.Gradient, settings.Gradient)
+	}
+	if settings.Image != nil {
+		target.Image = *settings.Image
+	}
+	if settings.Relative != nil {
+		target.Relative = *settings.Relative
+	}
+}
+
+// applyTextStrokeSettings applies TextStrokeSettings to TextStrokeConfig.
+func (cm *ConfigMerger) applyTextStrokeSettings(target *TextStrokeConfig, settings *TextStrokeSettings) {
+	if settings.Width != nil {
+		target.Width = *settings.Width
+	}
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+}
+
+// applyTextShadowSettings applies TextShadowSettings to TextShadowConfig.
+func (cm *ConfigMerger) applyTextShadowSettings(target *TextShadowConfig, settings *TextShadowSettings) {
+	if settings.OffsetX != nil {
+		target.OffsetX = *settings.OffsetX
+	}
+	if settings.OffsetY != nil {
+		target.OffsetY = *settings.OffsetY
+	}
+	if settings.Blur != nil {
+		target.Blur = *settings.Blur
+	}
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+}
+
+// applyTextGlowSettings applies TextGlowSettings to TextGlowConfig.
+func (cm *ConfigMerger) applyTextGlowSettings(target *TextGlowConfig, settings *TextGlowSettings) {
+	if settings.Radius != nil {
+		target.Radius = *settings.Radius
+	}
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+}
+
+// applyLineBackgroundSettings applies TextLineBackgroundSettings to TextLineBackgroundConfig.
+func (cm *ConfigMerger) applyLineBackgroundSettings(target *TextLineBackgroundConfig, settings *TextLineBackgroundSettings) {
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+	if settings.PaddingX != nil {
+		target.PaddingX = *settings.PaddingX
+	}
+	if settings.PaddingY != nil {
+		target.PaddingY = *settings.PaddingY
+	}
+	if settings.Radius != nil {
+		target.Radius = *settings.Radius
+	}
+	if settings.Opacity != nil {
+		target.Opacity = *settings.Opacity
+	}
+}
+
+// applyPanelSettings applies TextPanelSettings to TextPanelConfig.
+func (cm *ConfigMerger) applyPanelSettings(target *TextPanelConfig, settings *TextPanelSettings) {
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+	if settings.PaddingX != nil {
+		target.PaddingX = *settings.PaddingX
+	}
+	if settings.PaddingY != nil {
+		target.PaddingY = *settings.PaddingY
+	}
+	if settings.Radius != nil {
+		target.Radius = *settings.Radius
+	}
+	if settings.BorderWidth != nil {
+		target.BorderWidth = *settings.BorderWidth
+	}
+	if settings.BorderColor != nil {
+		target.BorderColor = *settings.BorderColor
+	}
+	if settings.Shadow != nil {
+		cm.applyTextShadowSettings(&target.Shadow, settings.Shadow)
+	}
+}
+
+// applyMarkupSettings applies TextMarkupSettings to TextMarkupConfig.
+func (cm *ConfigMerger) applyMarkupSettings(target *TextMarkupConfig, settings *TextMarkupSettings) {
+	if settings.Enabled != nil {
+		target.Enabled = *settings.Enabled
+	}
+	if settings.CodeFont != nil {
+		target.CodeFont = cm.copyStringPtr(settings.CodeFont)
+	}
+	if settings.Bold != nil {
+		cm.applyRunStyleSettings(&target.Bold, settings.Bold)
+	}
+	if settings.Italic != nil {
+		cm.applyRunStyleSettings(&target.Italic, settings.Italic)
+	}
+	if settings.Code != nil {
+		cm.applyRunStyleSettings(&target.Code, settings.Code)
+	}
+	if settings.Highlight != nil {
+		cm.applyRunStyleSettings(&target.Highlight, settings.Highlight)
+	}
+}
+
+// applyRunStyleSettings applies TextRunStyleSettings to TextRunStyleConfig.
+func (cm *ConfigMerger) applyRunStyleSettings(target *TextRunStyleConfig, settings *TextRunStyleSettings) {
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+	if settings.Background != nil {
+		target.Background = *settings.Background
+	}
+}
+
+// textElement returns the named text element of the config, or the text element
+// defaults if it has not been defined yet. The Texts map is created if necessary.
+func (cm *ConfigMerger) textElement(config *Config, name string) TextConfig {
+	if config.Texts == nil {
+		config.Texts = make(map[string]TextConfig)
+	}
+	if element, exists := config.Texts[name]; exists {
+		return element
+	}
+	return newTextElementConfig()
+}
+
 // applyTextAreaSettings applies TextAreaSettings to TextArea.
 func (cm *ConfigMerger) applyTextAreaSettings(target *TextArea, settings *TextAreaSettings) {
 	if settings.X != nil {
@@ -123,6 +487,131 @@ func (cm *ConfigMerger) applyTextAreaSettings(target *TextArea, settings *TextAr
 	}
 }
 
+// applyShapeSettings applies ShapeSettings to ShapeConfig.
+// It is used for both configuration files and front matter overrides.
+func (cm *ConfigMerger) applyShapeSettings(target *ShapeConfig, settings *ShapeSettings) {
+	if settings.Visible != nil {
+		target.Visible = *settings.Visible
+	}
+	if settings.Type != nil {
+		target.Type = *settings.Type
+	}
+	if settings.X != nil {
+		target.X = *settings.X
+	}
+	if settings.Y != nil {
+		target.Y = *settings.Y
+	}
+	if settings.Width != nil {
+		target.Width = *settings.Width
+	}
+	if settings.Height != nil {
+		target.Height = *settings.Height
+	}
+	if settings.Radius != nil {
+		target.Radius = *settings.Radius
+	}
+	if settings.Points != nil {
+		target.Points = append([]ShapePoint(nil), settings.Points...)
+	}
+	if settings.Fill != nil {
+		target.Fill = *settings.Fill
+	}
+	if settings.Stroke != nil {
+		target.Stroke = *settings.Stroke
+	}
+	if settings.StrokeWidth != nil {
+		target.StrokeWidth = *settings.StrokeWidth
+	}
+	if settings.Opacity != nil {
+		target.Opacity = *settings.Opacity
+	}
+	if settings.Z != nil {
+		target.Z = *settings.Z
+	}
+}
+
+// findOrAppendShape returns the shape with the given id, appending a new entry
+// with shape defaults if none exists. Empty ids always create a new entry.
+func (cm *ConfigMerger) findOrAppendShape(shapes *[]ShapeConfig, id string) *ShapeConfig {
+	if id != "" {
+		for i := range *shapes {
+			if (*shapes)[i].ID == id {
+				return &(*shapes)[i]
+			}
+		}
+	}
+
+	*shapes = append(*shapes, newShapeEntry(id))
+	return &(*shapes)[len(*shapes)-1]
+}
+
+// applyTagsSettings applies TagsSettings to TagsConfig.
+// It is used for both configuration files and front matter overrides.
+func (cm *ConfigMerger) applyTagsSettings(target *TagsConfig, settings *TagsSettings) {
+	if settings.Visible != nil {
+		target.Visible = *settings.Visible
+	}
+	if settings.Font != nil {
+		target.Font = cm.copyStringPtr(settings.Font)
+	}
+	if settings.Size != nil {
+		target.Size = *settings.Size
+	}
+	if settings.Weight != nil {
+		target.Weight = *settings.Weight
+	}
+	if settings.Color != nil {
+		target.Color = *settings.Color
+	}
+	if settings.Background != nil {
+		target.Background = *settings.Background
+	}
+	if settings.Area != nil {
+		cm.applyTextAreaSettings(&target.Area, settings.Area)
+	}
+	if settings.LineAlignment != nil {
+		target.LineAlignment = *settings.LineAlignment
+	}
+	if settings.PaddingX != nil {
+		target.PaddingX = *settings.PaddingX
+	}
+	if settings.PaddingY != nil {
+		target.PaddingY = *settings.PaddingY
+	}
+	if settings.Radius != nil {
+		target.Radius = *settings.Radius
+	}
+	if settings.Gap != nil {
+		target.Gap = *settings.Gap
+	}
+	if settings.Max != nil {
+		target.Max = *settings.Max
+	}
+	if settings.ShowMore != nil {
+		target.ShowMore = *settings.ShowMore
+	}
+	if settings.Z != nil {
+		target.Z = *settings.Z
+	}
+	for tag, colors := range settings.Colors {
+		if colors == nil {
+			continue
+		}
+		if target.Colors == nil {
+			target.Colors = make(map[string]TagColorConfig)
+		}
+		tagColors := target.Colors[tag]
+		if colors.Color != nil {
+			tagColors.Color = *colors.Color
+		}
+		if colors.Background != nil {
+			tagColors.Background = *colors.Background
+		}
+		target.Colors[tag] = tagColors
+	}
+}
+
 // applyLineBreakingSettings applies LineBreakingSettings to LineBreakingConfig.
 func (cm *ConfigMerger) applyLineBreakingSettings(target *LineBreakingConfig, settings *LineBreakingSettings) {
 	if settings.StartProhibited != nil {
@@ -154,11 +643,41 @@ func (cm *ConfigMerger) applyOverlaySettings(target *MainOverlayConfig, settings
 	if settings.Opacity != nil {
 		target.Opacity = *settings.Opacity
 	}
+	if settings.Blend != nil {
+		target.Blend = *settings.Blend
+	}
+	if settings.Z != nil {
+		target.Z = *settings.Z
+	}
 	if settings.Placement != nil {
 		cm.applyPlacementSettings(&target.Placement, settings.Placement)
 	}
 }
 
+// applyOverlayListSettings merges overlay list settings into the overlay list.
+// Entries with an id matching an existing overlay override it; other entries are appended.
+func (cm *ConfigMerger) applyOverlayListSettings(target *[]MainOverlayConfig, settings []OverlayConfigSettings) {
+	for i := range settings {
+		overlay := cm.findOrAppendOverlay(target, settings[i].ID)
+		cm.applyOverlaySettings(overlay, &settings[i])
+	}
+}
+
+// findOrAppendOverlay returns the overlay with the given id, appending a new entry
+// with list defaults if none exists. Empty ids always create a new entry.
+func (cm *ConfigMerger) findOrAppendOverlay(overlays *[]MainOverlayConfig, id string) *MainOverlayConfig {
+	if id != "" {
+		for i := range *overlays {
+			if (*overlays)[i].ID == id {
+				return &(*overlays)[i]
+			}
+		}
+	}
+
+	*overlays = append(*overlays, newOverlayListEntry(id))
+	return &(*overlays)[len(*overlays)-1]
+}
+
 // applyPlacementSettings applies PlacementSettings to PlacementConfig.
 func (cm *ConfigMerger) applyPlacementSettings(target *PlacementConfig, settings *PlacementSettings) {
 	if settings.X != nil {
@@ -229,14 +748,25 @@ func (cm *ConfigMerger) deepCopyConfig(config *Config) *Config {
 func (cm *ConfigMerger) deepCopyPointerFields(dest, src *Config) {
 	// Background pointers
 	dest.Background.Image = cm.copyStringPtr(src.Background.Image)
+	dest.Background.Cover.Fields = cm.copyStrings(src.Background.Cover.Fields)
+	dest.Background.Cover.Globs = cm.copyStrings(src.Background.Cover.Globs)
+	dest.Background.Filters = cm.copyBackgroundFilters(src.Background.Filters)
+	dest.Background.Generative.Palette = cm.copyStrings(src.Background.Generative.Palette)
+	dest.Background.Gradient.Stops = cm.copyGradientStops(src.Background.Gradient.Stops)
 
 	// Title pointers
 	dest.Title.Content = cm.copyStringPtr(src.Title.Content)
 	dest.Title.Font = cm.copyStringPtr(src.Title.Font)
+	dest.Title.Fill.Gradient.Stops = cm.copyGradientStops(src.Title.Fill.Gradient.Stops)
+	dest.Title.Markup.CodeFont = cm.copyStringPtr(src.Title.Markup.CodeFont)
+	dest.Title.Highlights = cm.copyHighlights(src.Title.Highlights)
 
 	// Description pointers
 	dest.Description.Content = cm.copyStringPtr(src.Description.Content)
 	dest.Description.Font = cm.copyStringPtr(src.Description.Font)
+	dest.Description.Fill.Gradient.Stops = cm.copyGradientStops(src.Description.Fill.Gradient.Stops)
+	dest.Description.Markup.CodeFont = cm.copyStringPtr(src.Description.Markup.CodeFont)
+	dest.Description.Highlights = cm.copyHighlights(src.Description.Highlights)
 
 	// Overlay pointers
 	dest.Overlay.Image = cm.copyStringPtr(src.Overlay.Image)
@@ -244,6 +774,72 @@ func (cm *ConfigMerger) deepCopyPointerFields(dest, src *Config) {
 	// Overlay placement pointers
 	dest.Overlay.Placement.Width = cm.copyIntPtr(src.Overlay.Placement.Width)
 	dest.Overlay.Placement.Height = cm.copyIntPtr(src.Overlay.Placement.Height)
+
+	// Overlay list
+	dest.Overlays = cm.copyOverlayList(src.Overlays)
+
+	// Named text elements
+	dest.Texts = cm.copyTextElements(src.Texts)
+
+	// Shape list
+	if src.Shapes != nil {
+		dest.Shapes = make([]ShapeConfig, len(src.Shapes))
+		for i, shape := range src.Shapes {
+			shape.Points = append([]ShapePoint(nil), shape.Points...)
+			dest.Shapes[i] = shape
+		}
+	}
+
+	// Tag chips pointers and color mappings
+	dest.Tags.Font = cm.copyStringPtr(src.Tags.Font)
+	if src.Tags.Colors != nil {
+		dest.Tags.Colors = make(map[string]TagColorConfig, len(src.Tags.Colors))
+		for tag, colors := range src.Tags.Colors {
+			dest.Tags.Colors[tag] = colors
+		}
+	}
+}
+
+// copyTextElements creates a deep copy of the named text elements
+func (cm *ConfigMerger) copyTextElements(src map[string]TextConfig) map[string]TextConfig {
+	if src == nil {
+		return nil
+	}
+
+	texts := make(map[string]TextConfig, len(src))
+	for name, element := range src {
+		element.Content = cm.copyStringPtr(element.Content)
+		element.Font = cm.copyStringPtr(element.Font)
+		element.Fill.Gradient.Stops = cm.copyGradientStops(element.Fill.Gradient.Stops)
+		element.Markup.CodeFont = cm.copyStringPtr(element.Markup.CodeFont)
+		element.Highlights = cm.copyHighlights(element.Highlights)
+		texts[name] = element
+	}
+	return texts
+}
+
+// copyOverlayList creates a deep copy of an overlay list
+func (cm *ConfigMerger) copyOverlayList(src []MainOverlayConfig) []MainOverlayConfig {
+	if src == nil {
+		return nil
+	}
+
+	overlays := make([]MainOverlayConfig, len(src))
+	for i, overlay := range src {
+		overlays[i] = overlay
+		overlays[i].Image = cm.copyStringPtr(overlay.Image)
+		overlays[i].Placement.Width = cm.copyIntPtr(overlay.Placement.Width)
+		overlays[i].Placement.Height = cm.copyIntPtr(overlay.Placement.Height)
+	}
+	return overlays
+}
+
+// copyStrings creates a copy of a string slice
+func (cm *ConfigMerger) copyStrings(src []string) []string {
+	if src == nil {
+		return nil
+	}
+	return append([]string{}, src...)
 }
 
 // copyStringPtr creates a deep copy of a string pointer
@@ -264,6 +860,15 @@ func (cm *ConfigMerger) copyIntPtr(src *int) *int {
 	return &copy
 }
 
+// copyFloat64Ptr creates a deep copy of a float64 pointer
+func (cm *ConfigMerger) copyFloat64Ptr(src *float64) *float64 {
+	if src == nil {
+		return nil
+	}
+	copy := *src
+	return &copy
+}
+
 // applyFrontMatterOverrides applies front matter overrides to a config
 func (cm *ConfigMerger) applyFrontMatterOverrides(config *Config, ogpFM *OGPFrontMatter) *Config {
 	result := cm.deepCopyConfig(config)
@@ -275,6 +880,21 @@ func (cm *ConfigMerger) applyFrontMatterOverrides(config *Config, ogpFM *OGPFron
 	if ogpFM.Description != nil {
 		cm.mergeTextConfigOverride(&result.Description, ogpFM.Description)
 	}
+	for name, override := range ogpFM.Texts {
+		element := cm.textElement(result, name)
+		if override != nil {
+			cm.mergeTextConfigOverride(&element, override)
+		}
+		result.Texts[name] = element
+	}
+
+	if ogpFM.Tags != nil {
+		cm.applyTagsSettings(&result.Tags, ogpFM.Tags)
+	}
+	for i := range ogpFM.Shapes {
+		shape := cm.findOrAppendShape(&result.Shapes, ogpFM.Shapes[i].ID)
+		cm.applyShapeSettings(shape, &ogpFM.Shapes[i])
+	}
 
 	cm.mergeBackgroundConfig(result, ogpFM)
 	cm.mergeOutputConfig(result, ogpFM)
@@ -298,6 +918,15 @@ func (cm *ConfigMerger) mergeTextConfigOverride(config *TextConfig, override *Te
 	if override.Size != nil {
 		config.Size = *override.Size
 	}
+	if override.Weight != nil {
+		config.Weight = *override.Weight
+	}
+	if override.Width != nil {
+		config.Width = *override.Width
+	}
+	if override.Italic != nil {
+		config.Italic = *override.Italic
+	}
 	if override.Color != nil {
 		config.Color = *override.Color
 	}
@@ -313,12 +942,54 @@ func (cm *ConfigMerger) mergeTextConfigOverride(config *TextConfig, override *Te
 	if override.MinSize != nil {
 		config.MinSize = *override.MinSize
 	}
+	if override.MaxSize != nil {
+		config.MaxSize = *override.MaxSize
+	}
+	if override.MaxLines != nil {
+		config.MaxLines = *override.MaxLines
+	}
+	if override.Ellipsis != nil {
+		config.Ellipsis = *override.Ellipsis
+	}
+	if override.Wrap != nil {
+		config.Wrap = *override.Wrap
+	}
 	if override.LineHeight != nil {
 		config.LineHeight = *override.LineHeight
 	}
 	if override.LetterSpacing != nil {
 		config.LetterSpacing = *override.LetterSpacing
 	}
+	if override.Kerning != nil {
+		config.Kerning = *override.Kerning
+	}
+	if override.Fill != nil {
+		cm.applyTextFillSettings(&config.Fill, override.Fill)
+	}
+	if override.Stroke != nil {
+		cm.applyTextStrokeSettings(&config.Stroke, override.Stroke)
+	}
+	if override.Shadow != nil {
+		cm.applyTextShadowSettings(&config.Shadow, override.Shadow)
+	}
+	if override.Glow != nil {
+		cm.applyTextGlowSettings(&config.Glow, override.Glow)
+	}
+	if override.LineBackground != nil {
+		cm.applyLineBackgroundSettings(&config.LineBackground, override.LineBackground)
+	}
+	if override.Panel != nil {
+		cm.applyPanelSettings(&config.Panel, override.Panel)
+	}
+	if override.Markup != nil {
+		cm.applyMarkupSettings(&config.Markup, override.Markup)
+	}
+	if override.Highlights != nil {
+		config.Highlights = cm.copyHighlights(override.Highlights)
+	}
+	if override.Z != nil {
+		config.Z = *override.Z
+	}
 	if override.Area != nil {
 		cm.mergeTextAreaConfig(&config.Area, override.Area)
 	}
@@ -365,12 +1036,39 @@ func (cm *ConfigMerger) mergeBackgroundConfig(config *Config, ogpFM *OGPFrontMat
 	if ogpFM.Background == nil {
 		return
 	}
+	if ogpFM.Background.Source != nil {
+		config.Background.Source = *ogpFM.Background.Source
+	}
+	if ogpFM.Background.Cover != nil {
+		cm.applyCoverSourceSettings(&config.Background.Cover, ogpFM.Background.Cover)
+	}
 	if ogpFM.Background.Image != nil {
 		config.Background.Image = ogpFM.Background.Image
 	}
 	if ogpFM.Background.Color != nil {
 		config.Background.Color = *ogpFM.Background.Color
 	}
+	if ogpFM.Background.Fit != nil {
+		config.Background.Fit = *ogpFM.Background.Fit
+	}
+	if ogpFM.Background.FocalPoint != nil {
+		cm.applyFocalPointSettings(&config.Background.FocalPoint, ogpFM.Background.FocalPoint)
+	}
+	if ogpFM.Background.Filters != nil {
+		config.Background.Filters = cm.copyBackgroundFilters(ogpFM.Background.Filters)
+	}
+	if ogpFM.Background.Generative != nil {
+		cm.applyGenerativeSettings(&config.Background.Generative, ogpFM.Background.Generative)
+	}
+	if ogpFM.Background.Gradient != nil {
+		cm.applyGradientSettings(&config.Background.Gradient, ogpFM.Background.Gradient)
+	}
+	if ogpFM.Background.Pattern != nil {
+		cm.applyPatternSettings(&config.Background.Pattern, ogpFM.Background.Pattern)
+	}
+	if ogpFM.Background.Z != nil {
+		config.Background.Z = *ogpFM.Background.Z
+	}
 }
 
 func (cm *ConfigMerger) mergeOutputConfig(config *Config, ogpFM *OGPFrontMatter) {
@@ -386,25 +1084,38 @@ func (cm *ConfigMerger) mergeOutputConfig(config *Config, ogpFM *OGPFrontMatter)
 
 // mergeOverlayConfig applies overlay overrides
 func (cm *ConfigMerger) mergeOverlayConfig(config *Config, ogpFM *OGPFrontMatter) {
-	if ogpFM.Overlay == nil {
-		return
+	if ogpFM.Overlay != nil {
+		cm.mergeArticleOverlay(&config.Overlay, ogpFM.Overlay)
 	}
 
-	overlay := ogpFM.Overlay
+	for i := range ogpFM.Overlays {
+		overlay := cm.findOrAppendOverlay(&config.Overlays, ogpFM.Overlays[i].ID)
+		cm.mergeArticleOverlay(overlay, &ogpFM.Overlays[i])
+	}
+}
+
+// mergeArticleOverlay applies a front matter overlay override to a single overlay
+func (cm *ConfigMerger) mergeArticleOverlay(target *MainOverlayConfig, overlay *ArticleOverlayConfig) {
 	if overlay.Visible != nil {
-		config.Overlay.Visible = *overlay.Visible
+		target.Visible = *overlay.Visible
 	}
 	if overlay.Image != nil {
-		config.Overlay.Image = overlay.Image
+		target.Image = cm.copyStringPtr(overlay.Image)
 	}
 	if overlay.Fit != nil {
-		config.Overlay.Fit = *overlay.Fit
+		target.Fit = *overlay.Fit
 	}
 	if overlay.Opacity != nil {
-		config.Overlay.Opacity = *overlay.Opacity
+		target.Opacity = *overlay.Opacity
+	}
+	if overlay.Blend != nil {
+		target.Blend = *overlay.Blend
+	}
+	if overlay.Z != nil {
+		target.Z = *overlay.Z
 	}
 	if overlay.Placement != nil {
-		cm.applyPlacementSettings(&config.Overlay.Placement, overlay.Placement)
+		cm.applyPlacementSettings(&target.Placement, overlay.Placement)
 	}
 }
 
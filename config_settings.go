@@ -13,14 +13,74 @@ type ConfigSettings struct {
 	Title       *TextSettings `yaml:"title,omitempty"`       // Title text configuration
 	Description *TextSettings `yaml:"description,omitempty"` // Description text configuration
 
+	// Named text elements, merged by name
+	Texts map[string]*TextSettings `yaml:"texts,omitempty"`
+
+	// Tag chips configuration
+	Tags *TagsSettings `yaml:"tags,omitempty"`
+
+	// Vector shapes, merged by id
+	Shapes []ShapeSettings `yaml:"shapes,omitempty"`
+
 	// Default overlay configuration
 	Overlay *OverlayConfigSettings `yaml:"overlay,omitempty"`
+
+	// Additional overlays, merged by id
+	Overlays []OverlayConfigSettings `yaml:"overlays,omitempty"`
 }
 
 // BackgroundSettings represents background configuration for YAML reading.
 type BackgroundSettings struct {
-	Image *string `yaml:"image,omitempty"` // Path to background image
-	Color *string `yaml:"color,omitempty"` // Background color (hex)
+	Source     *string              `yaml:"source,omitempty"`      // Background source ("config" or "cover")
+	Cover      *CoverSourceSettings `yaml:"cover,omitempty"`       // Cover image lookup settings
+	Image      *string              `yaml:"image,omitempty"`       // Path to background image
+	Color      *string              `yaml:"color,omitempty"`       // Background color (hex)
+	Fit        *string              `yaml:"fit,omitempty"`         // Fit method ("cover", "contain", "fill", "none")
+	FocalPoint *FocalPointSettings  `yaml:"focal_point,omitempty"` // Focal point settings
+	Filters    []BackgroundFilter   `yaml:"filters,omitempty"`     // Filter chain (replaces inherited filters)
+	Generative *GenerativeSettings  `yaml:"generative,omitempty"`  // Generative background settings
+	Gradient   *GradientSettings    `yaml:"gradient,omitempty"`    // Gradient settings
+	Pattern    *PatternSettings     `yaml:"pattern,omitempty"`     // Pattern settings
+	Z          *int                 `yaml:"z,omitempty"`           // Stacking order (higher values are drawn on top)
+}
+
+// CoverSourceSettings represents cover image lookup configuration for YAML reading and front matter overrides.
+type CoverSourceSettings struct {
+	Fields []string `yaml:"fields,omitempty"` // Front matter fields (replaces inherited fields)
+	Globs  []string `yaml:"globs,omitempty"`  // Page bundle file patterns (replaces inherited patterns)
+}
+
+// FocalPointSettings represents focal point configuration for YAML reading and front matter overrides.
+type FocalPointSettings struct {
+	X *float64 `yaml:"x,omitempty"` // Horizontal position (0.0-1.0)
+	Y *float64 `yaml:"y,omitempty"` // Vertical position (0.0-1.0)
+}
+
+// GenerativeSettings represents generative background configuration for YAML reading and front matter overrides.
+type GenerativeSettings struct {
+	Type    *string  `yaml:"type,omitempty"`    // Generative type ("mesh", "blobs", "tiles", empty for none)
+	Seed    *string  `yaml:"seed,omitempty"`    // Seed template
+	Palette []string `yaml:"palette,omitempty"` // Colors (replaces inherited palette)
+	Count   *int     `yaml:"count,omitempty"`   // Number of mesh points or blobs, or rows of tiles
+}
+
+// GradientSettings represents gradient configuration for YAML reading and front matter overrides.
+type GradientSettings struct {
+	Type    *string        `yaml:"type,omitempty"`     // Gradient type ("linear", "radial", "conic", empty for none)
+	Angle   *float64       `yaml:"angle,omitempty"`    // Gradient angle in degrees
+	Stops   []GradientStop `yaml:"stops,omitempty"`    // Color stops (replaces inherited stops)
+	CenterX *float64       `yaml:"center_x,omitempty"` // Horizontal center (0.0-1.0)
+	CenterY *float64       `yaml:"center_y,omitempty"` // Vertical center (0.0-1.0)
+	Dither  *bool          `yaml:"dither,omitempty"`   // Whether to apply dithering
+}
+
+// PatternSettings represents pattern configuration for YAML reading and front matter overrides.
+type PatternSettings struct {
+	Type      *string  `yaml:"type,omitempty"`      // Pattern type ("dots", "stripes", "grid", empty for none)
+	Color     *string  `yaml:"color,omitempty"`     // Pattern color (hex)
+	Size      *int     `yaml:"size,omitempty"`      // Pattern spacing in pixels
+	Thickness *float64 `yaml:"thickness,omitempty"` // Dot diameter or line width in pixels
+	Angle     *float64 `yaml:"angle,omitempty"`     // Stripe angle in degrees
 }
 
 // OutputSettings represents output configuration for YAML reading.
@@ -39,11 +99,15 @@ type TextSettings struct {
 	Content *string `yaml:"content,omitempty"` // Content template
 
 	// Font configuration
-	Font *string  `yaml:"font,omitempty"` // Path to font file
-	Size *float64 `yaml:"size,omitempty"` // Font size
+	Font   *string  `yaml:"font,omitempty"`   // Path to font file
+	Size   *float64 `yaml:"size,omitempty"`   // Font size
+	Weight *int     `yaml:"weight,omitempty"` // Font weight (100-900)
+	Width  *float64 `yaml:"width,omitempty"`  // Font width in percent
+	Italic *bool    `yaml:"italic,omitempty"` // Whether to render italic text
 
 	// Text color configuration
-	Color *string `yaml:"color,omitempty"` // Hex color code
+	Color *string           `yaml:"color,omitempty"` // Hex color code
+	Fill  *TextFillSettings `yaml:"fill,omitempty"`  // Gradient or image texture painted into the glyphs
 
 	// Text rendering area coordinates
 	Area *TextAreaSettings `yaml:"area,omitempty"`
@@ -51,19 +115,109 @@ type TextSettings struct {
 	// Text layout configuration
 	BlockPosition *string `yaml:"block_position,omitempty"` // Text block position in area
 	LineAlignment *string `yaml:"line_alignment,omitempty"` // Individual line alignment within block
-	Overflow      *string `yaml:"overflow,omitempty"`       // Overflow handling ("shrink" or "clip")
+	Overflow      *string `yaml:"overflow,omitempty"`       // Overflow handling ("shrink", "clip", "ellipsis", "shrink-then-ellipsis" or "fit")
 
 	// Font sizing configuration
-	MinSize *float64 `yaml:"min_size,omitempty"` // Minimum font size for shrink mode
+	MinSize  *float64 `yaml:"min_size,omitempty"`  // Minimum font size for shrink mode
+	MaxSize  *float64 `yaml:"max_size,omitempty"`  // Maximum font size for fit mode
+	MaxLines *int     `yaml:"max_lines,omitempty"` // Maximum number of lines (0 for no limit)
+	Ellipsis *string  `yaml:"ellipsis,omitempty"`  // Text appended to truncated text in ellipsis modes
+
+	// Line wrapping configuration
+	Wrap *string `yaml:"wrap,omitempty"` // Line wrapping ("greedy" or "balanced")
 
 	// Text spacing configuration
 	LineHeight    *float64 `yaml:"line_height,omitempty"`    // Line height multiplier
 	LetterSpacing *int     `yaml:"letter_spacing,omitempty"` // Letter spacing in pixels
+	Kerning       *bool    `yaml:"kerning,omitempty"`        // Whether to apply font kerning pairs
+
+	// Text effects
+	Stroke *TextStrokeSettings `yaml:"stroke,omitempty"` // Outline around the glyphs
+	Shadow *TextShadowSettings `yaml:"shadow,omitempty"` // Drop shadow
+	Glow   *TextGlowSettings   `yaml:"glow,omitempty"`   // Soft glow around the glyphs
+
+	// Marker box drawn behind each line
+	LineBackground *TextLineBackgroundSettings `yaml:"line_background,omitempty"`
+
+	// Card drawn behind the whole text block
+	Panel *TextPanelSettings `yaml:"panel,omitempty"`
+
+	// Inline markup for styled runs within the text
+	Markup *TextMarkupSettings `yaml:"markup,omitempty"`
+
+	// Rules that style matches of patterns or words (replaces inherited rules)
+	Highlights []TextHighlightConfig `yaml:"highlights,omitempty"`
+
+	// Layer configuration
+	Z *int `yaml:"z,omitempty"` // Stacking order (higher values are drawn on top)
 
 	// Japanese line breaking rules configuration
 	LineBreaking *LineBreakingSettings `yaml:"line_breaking,omitempty"`
 }
 
+// TextFillSettings represents text fill configuration for YAML reading and front matter overrides.
+type TextFillSettings struct {
+	Gradient *GradientSettings `yaml:"gradient,omitempty"` // Gradient fill
+	Image    *string           `yaml:"image,omitempty"`    // Texture image path
+	Relative *string           `yaml:"relative,omitempty"` // Area the fill spans ("text" or "canvas")
+}
+
+// TextStrokeSettings represents text outline configuration for YAML reading and front matter overrides.
+type TextStrokeSettings struct {
+	Width *float64 `yaml:"width,omitempty"` // Outline width in pixels
+	Color *string  `yaml:"color,omitempty"` // Outline color (hex)
+}
+
+// TextShadowSettings represents drop shadow configuration for YAML reading and front matter overrides.
+type TextShadowSettings struct {
+	OffsetX *int     `yaml:"offset_x,omitempty"` // Horizontal offset in pixels
+	OffsetY *int     `yaml:"offset_y,omitempty"` // Vertical offset in pixels
+	Blur    *float64 `yaml:"blur,omitempty"`     // Blur radius in pixels
+	Color   *string  `yaml:"color,omitempty"`    // Shadow color (hex)
+}
+
+// TextGlowSettings represents glow configuration for YAML reading and front matter overrides.
+type TextGlowSettings struct {
+	Radius *float64 `yaml:"radius,omitempty"` // Glow radius in pixels
+	Color  *string  `yaml:"color,omitempty"`  // Glow color (hex)
+}
+
+// TextLineBackgroundSettings represents line background configuration for YAML reading and front matter overrides.
+type TextLineBackgroundSettings struct {
+	Color    *string  `yaml:"color,omitempty"`     // Box color (hex, empty for none)
+	PaddingX *int     `yaml:"padding_x,omitempty"` // Horizontal padding around each line
+	PaddingY *int     `yaml:"padding_y,omitempty"` // Vertical padding around each line
+	Radius   *float64 `yaml:"radius,omitempty"`    // Corner radius
+	Opacity  *float64 `yaml:"opacity,omitempty"`   // Box opacity (0.0-1.0)
+}
+
+// TextPanelSettings represents text panel configuration for YAML reading and front matter overrides.
+type TextPanelSettings struct {
+	Color       *string             `yaml:"color,omitempty"`        // Panel color (hex, empty for no fill)
+	PaddingX    *int                `yaml:"padding_x,omitempty"`    // Horizontal padding around the text block
+	PaddingY    *int                `yaml:"padding_y,omitempty"`    // Vertical padding around the text block
+	Radius      *float64            `yaml:"radius,omitempty"`       // Corner radius
+	BorderWidth *float64            `yaml:"border_width,omitempty"` // Border width in pixels
+	BorderColor *string             `yaml:"border_color,omitempty"` // Border color (hex)
+	Shadow      *TextShadowSettings `yaml:"shadow,omitempty"`       // Drop shadow cast by the panel
+}
+
+// TextMarkupSettings represents inline markup configuration for YAML reading and front matter overrides.
+type TextMarkupSettings struct {
+	Enabled   *bool                 `yaml:"enabled,omitempty"`   // Whether to parse inline markup
+	CodeFont  *string               `yaml:"code_font,omitempty"` // Path to the font of code runs
+	Bold      *TextRunStyleSettings `yaml:"bold,omitempty"`      // Style of **bold** runs
+	Italic    *TextRunStyleSettings `yaml:"italic,omitempty"`    // Style of *italic* runs
+	Code      *TextRunStyleSettings `yaml:"code,omitempty"`      // Style of `code` runs
+	Highlight *TextRunStyleSettings `yaml:"highlight,omitempty"` // Style of ==highlight== runs
+}
+
+// TextRunStyleSettings represents marked up run colors for YAML reading and front matter overrides.
+type TextRunStyleSettings struct {
+	Color      *string `yaml:"color,omitempty"`      // Text color (hex)
+	Background *string `yaml:"background,omitempty"` // Box color behind the run (hex, empty for none)
+}
+
 // TextAreaSettings represents text area configuration for YAML reading.
 type TextAreaSettings struct {
 	X      *int `yaml:"x,omitempty"`
@@ -78,13 +232,60 @@ type LineBreakingSettings struct {
 	EndProhibited   *string `yaml:"end_prohibited,omitempty"`   // Characters that cannot end a line
 }
 
+// ShapeSettings represents shape configuration for YAML reading and front matter overrides.
+type ShapeSettings struct {
+	ID          string       `yaml:"id,omitempty"`           // Identifier used to merge shapes
+	Visible     *bool        `yaml:"visible,omitempty"`      // Whether to render this shape
+	Type        *string      `yaml:"type,omitempty"`         // Shape type
+	X           *int         `yaml:"x,omitempty"`            // Left edge of the shape box
+	Y           *int         `yaml:"y,omitempty"`            // Top edge of the shape box
+	Width       *int         `yaml:"width,omitempty"`        // Width of the shape box
+	Height      *int         `yaml:"height,omitempty"`       // Height of the shape box
+	Radius      *float64     `yaml:"radius,omitempty"`       // Corner radius for rounded rectangles
+	Points      []ShapePoint `yaml:"points,omitempty"`       // Vertices for lines and polygons (replaces inherited points)
+	Fill        *string      `yaml:"fill,omitempty"`         // Fill color (hex, empty for no fill)
+	Stroke      *string      `yaml:"stroke,omitempty"`       // Stroke color (hex, empty for no stroke)
+	StrokeWidth *float64     `yaml:"stroke_width,omitempty"` // Stroke width in pixels
+	Opacity     *float64     `yaml:"opacity,omitempty"`      // Shape opacity (0.0-1.0)
+	Z           *int         `yaml:"z,omitempty"`            // Stacking order
+}
+
+// TagsSettings represents tag chips configuration for YAML reading and front matter overrides.
+type TagsSettings struct {
+	Visible       *bool                        `yaml:"visible,omitempty"`        // Whether to render tag chips
+	Font          *string                      `yaml:"font,omitempty"`           // Path to font file
+	Size          *float64                     `yaml:"size,omitempty"`           // Font size
+	Weight        *int                         `yaml:"weight,omitempty"`         // Font weight (100-900)
+	Color         *string                      `yaml:"color,omitempty"`          // Default chip text color (hex)
+	Background    *string                      `yaml:"background,omitempty"`     // Default chip background color (hex)
+	Colors        map[string]*TagColorSettings `yaml:"colors,omitempty"`         // Per-tag color mappings, merged by tag
+	Area          *TextAreaSettings            `yaml:"area,omitempty"`           // Area the chips flow into
+	LineAlignment *string                      `yaml:"line_alignment,omitempty"` // Row alignment
+	PaddingX      *int                         `yaml:"padding_x,omitempty"`      // Horizontal padding inside each chip
+	PaddingY      *int                         `yaml:"padding_y,omitempty"`      // Vertical padding inside each chip
+	Radius        *float64                     `yaml:"radius,omitempty"`         // Corner radius
+	Gap           *int                         `yaml:"gap,omitempty"`            // Spacing between chips and rows
+	Max           *int                         `yaml:"max,omitempty"`            // Maximum number of chips (0 means unlimited)
+	ShowMore      *bool                        `yaml:"show_more,omitempty"`      // Whether to add a "+N" chip for omitted tags
+	Z             *int                         `yaml:"z,omitempty"`              // Stacking order
+}
+
+// TagColorSettings represents the colors of a single tag chip for YAML reading.
+type TagColorSettings struct {
+	Color      *string `yaml:"color,omitempty"`      // Text color (hex)
+	Background *string `yaml:"background,omitempty"` // Background color (hex)
+}
+
 // OverlayConfigSettings represents overlay configuration for YAML reading.
 type OverlayConfigSettings struct {
+	ID        string             `yaml:"id,omitempty"`        // Identifier used to merge overlays (overlays list only)
 	Visible   *bool              `yaml:"visible,omitempty"`   // Whether to render this overlay
 	Image     *string            `yaml:"image,omitempty"`     // Path to image file
 	Placement *PlacementSettings `yaml:"placement,omitempty"` // Image positioning
 	Fit       *string            `yaml:"fit,omitempty"`       // Fit method
 	Opacity   *float64           `yaml:"opacity,omitempty"`   // Image opacity (0.0-1.0)
+	Blend     *string            `yaml:"blend,omitempty"`     // Blend space ("srgb", "linear")
+	Z         *int               `yaml:"z,omitempty"`         // Stacking order (higher values are drawn on top)
 }
 
 // PlacementSettings represents placement configuration for YAML reading.
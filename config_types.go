@@ -9,8 +9,153 @@ type OutputConfig struct {
 
 // BackgroundConfig represents complete background configuration (runtime use)
 type BackgroundConfig struct {
-	Image *string `yaml:"image"` // Path to background image (nil if none)
-	Color string  `yaml:"color"` // Background color (hex)
+	Source     string             `yaml:"source"`      // Background source ("config" or "cover")
+	Cover      CoverSourceConfig  `yaml:"cover"`       // Where to look for the article cover image
+	Image      *string            `yaml:"image"`       // Path to background image (nil if none)
+	Color      string             `yaml:"color"`       // Background color (hex)
+	Fit        string             `yaml:"fit"`         // How the image is scaled to the canvas ("cover", "contain", "fill", "none")
+	FocalPoint FocalPointConfig   `yaml:"focal_point"` // Point of the image kept visible when cropping with cover
+	Filters    []BackgroundFilter `yaml:"filters"`     // Filters applied in order
+	Generative GenerativeConfig   `yaml:"generative"`  // Generative background seeded per article
+	Gradient   GradientConfig     `yaml:"gradient"`    // Gradient drawn instead of the solid color
+	Pattern    PatternConfig      `yaml:"pattern"`     // Repeating pattern drawn over the background
+	Z          int                `yaml:"z"`           // Stacking order (higher values are drawn on top)
+}
+
+// CoverSourceConfig represents where the cover image of an article is looked up.
+type CoverSourceConfig struct {
+	Fields []string `yaml:"fields"` // Front matter fields checked in order (dotted paths such as "cover.image")
+	Globs  []string `yaml:"globs"`  // Page bundle file patterns checked after the fields
+}
+
+// FocalPointConfig represents a point of an image as fractions of its size.
+type FocalPointConfig struct {
+	X float64 `yaml:"x"` // Horizontal position (0.0 left to 1.0 right)
+	Y float64 `yaml:"y"` // Vertical position (0.0 top to 1.0 bottom)
+}
+
+// BackgroundFilter represents a single filter of the background filter chain.
+// Unset values fall back to defaults for the filter type.
+type BackgroundFilter struct {
+	Type      string   `yaml:"type"`      // Filter type ("blur", "darken", "brighten", "grayscale", "duotone", "tint")
+	Amount    *float64 `yaml:"amount"`    // Blur radius in pixels, or strength (0.0-1.0) of darken, brighten and grayscale
+	Color     string   `yaml:"color"`     // Tint color (hex)
+	Opacity   *float64 `yaml:"opacity"`   // Tint opacity (0.0-1.0)
+	Shadow    string   `yaml:"shadow"`    // Duotone color for dark tones (hex)
+	Highlight string   `yaml:"highlight"` // Duotone color for light tones (hex)
+}
+
+// GenerativeConfig represents a generative background that is unique but stable per article.
+type GenerativeConfig struct {
+	Type    string   `yaml:"type"`    // Generative type ("mesh", "blobs", "tiles", empty for none)
+	Seed    string   `yaml:"seed"`    // Seed template (empty uses the slug or title)
+	Palette []string `yaml:"palette"` // Colors to draw from (hex)
+	Count   int      `yaml:"count"`   // Number of mesh points or blobs, or rows of tiles (0 for the type default)
+}
+
+// GradientConfig represents a generated gradient background.
+// Angles follow CSS: 0 points up and angles increase clockwise.
+type GradientConfig struct {
+	Type    string         `yaml:"type"`     // Gradient type ("linear", "radial", "conic", empty for none)
+	Angle   float64        `yaml:"angle"`    // Direction of linear gradients, start angle of conic gradients (degrees)
+	Stops   []GradientStop `yaml:"stops"`    // Color stops in order
+	CenterX float64        `yaml:"center_x"` // Center of radial and conic gradients (0.0-1.0 of the width)
+	CenterY float64        `yaml:"center_y"` // Center of radial and conic gradients (0.0-1.0 of the height)
+	Dither  bool           `yaml:"dither"`   // Whether to apply ordered dithering to avoid banding
+}
+
+// GradientStop represents a color stop of a gradient.
+type GradientStop struct {
+	Color    string   `yaml:"color"`    // Stop color (hex)
+	Position *float64 `yaml:"position"` // Position along the gradient (0.0-1.0, nil to space stops evenly)
+}
+
+// PatternConfig represents a generated repeating pattern.
+type PatternConfig struct {
+	Type      string  `yaml:"type"`      // Pattern type ("dots", "stripes", "grid", empty for none)
+	Color     string  `yaml:"color"`     // Pattern color (hex, #RRGGBBAA for translucent patterns)
+	Size      int     `yaml:"size"`      // Distance between dots, stripes or grid lines in pixels
+	Thickness float64 `yaml:"thickness"` // Dot diameter or line width in pixels
+	Angle     float64 `yaml:"angle"`     // Stripe angle in degrees
+}
+
+// TextFillConfig represents a gradient or image texture painted into the glyphs instead of the text color.
+// A gradient takes precedence over an image; without either the text color is used.
+type TextFillConfig struct {
+	Gradient GradientConfig `yaml:"gradient"` // Gradient fill (type empty for none)
+	Image    string         `yaml:"image"`    // Texture image path, scaled to cover the fill area (empty for none)
+	Relative string         `yaml:"relative"` // Area the fill spans ("text" for the text block, "canvas" for the whole image)
+}
+
+// TextStrokeConfig represents an outline drawn around text.
+type TextStrokeConfig struct {
+	Width float64 `yaml:"width"` // Outline width in pixels (0 for none)
+	Color string  `yaml:"color"` // Outline color (hex)
+}
+
+// TextShadowConfig represents a drop shadow drawn behind text.
+// The shadow is drawn when it has an offset or a blur.
+type TextShadowConfig struct {
+	OffsetX int     `yaml:"offset_x"` // Horizontal offset in pixels
+	OffsetY int     `yaml:"offset_y"` // Vertical offset in pixels
+	Blur    float64 `yaml:"blur"`     // Blur radius in pixels
+	Color   string  `yaml:"color"`    // Shadow color (hex, #RRGGBBAA for translucent shadows)
+}
+
+// TextGlowConfig represents a soft glow drawn around text.
+type TextGlowConfig struct {
+	Radius float64 `yaml:"radius"` // Glow radius in pixels (0 for none)
+	Color  string  `yaml:"color"`  // Glow color (hex, #RRGGBBAA for translucent glows)
+}
+
+// TextLineBackgroundConfig represents a marker box drawn behind each line of text.
+// Each box spans the measured width of its line and the ascent and descent of the font.
+type TextLineBackgroundConfig struct {
+	Color    string  `yaml:"color"`     // Box color (hex, empty for none)
+	PaddingX int     `yaml:"padding_x"` // Horizontal padding around each line
+	PaddingY int     `yaml:"padding_y"` // Vertical padding around each line
+	Radius   float64 `yaml:"radius"`    // Corner radius (clamped to half the box height)
+	Opacity  float64 `yaml:"opacity"`   // Box opacity (0.0-1.0)
+}
+
+// TextPanelConfig represents a card drawn behind a text block.
+// The panel follows the size and position of the laid out text, extended by the padding.
+type TextPanelConfig struct {
+	Color       string           `yaml:"color"`        // Panel color (hex, empty for no fill)
+	PaddingX    int              `yaml:"padding_x"`    // Horizontal padding around the text block
+	PaddingY    int              `yaml:"padding_y"`    // Vertical padding around the text block
+	Radius      float64          `yaml:"radius"`       // Corner radius (clamped to half the shorter side)
+	BorderWidth float64          `yaml:"border_width"` // Border width in pixels, centered on the panel outline (0 for none)
+	BorderColor string           `yaml:"border_color"` // Border color (hex)
+	Shadow      TextShadowConfig `yaml:"shadow"`       // Drop shadow cast by the panel
+}
+
+// TextMarkupConfig represents inline markup parsed from the text into styled runs:
+// **bold**, *italic*, `code` and ==highlight==. A backslash escapes a marker character.
+type TextMarkupConfig struct {
+	Enabled   bool               `yaml:"enabled"`   // Whether to parse inline markup
+	CodeFont  *string            `yaml:"code_font"` // Path to the font of code runs (nil means the embedded Go Mono)
+	Bold      TextRunStyleConfig `yaml:"bold"`      // Style of **bold** runs
+	Italic    TextRunStyleConfig `yaml:"italic"`    // Style of *italic* runs
+	Code      TextRunStyleConfig `yaml:"code"`      // Style of `code` runs
+	Highlight TextRunStyleConfig `yaml:"highlight"` // Style of ==highlight== runs
+}
+
+// TextRunStyleConfig represents the colors of a marked up run.
+type TextRunStyleConfig struct {
+	Color      string `yaml:"color"`      // Text color (hex, empty keeps the text color or fill)
+	Background string `yaml:"background"` // Box color behind the run (hex, empty for none)
+}
+
+// TextHighlightConfig represents a rule that styles every match of a pattern or word list in the text.
+// Rules are applied in order after inline markup, and earlier rules take precedence where matches overlap.
+type TextHighlightConfig struct {
+	Pattern    string   `yaml:"pattern"`     // Regular expression (RE2 syntax)
+	Words      []string `yaml:"words"`       // Words matched literally (Latin words only at word boundaries)
+	IgnoreCase bool     `yaml:"ignore_case"` // Whether matching ignores case
+	Color      string   `yaml:"color"`       // Text color of matches (hex, empty keeps the text color)
+	Background string   `yaml:"background"`  // Box color behind matches (hex, empty for none)
+	Font       *string  `yaml:"font"`        // Path to the font of matches (nil keeps the text font)
 }
 
 // LineBreakingConfig represents Japanese line breaking rules configuration.
@@ -47,30 +192,100 @@ type OverlayConfigBase struct {
 	Placement *PlacementConfig `yaml:"placement,omitempty"` // Image positioning
 	Fit       *string          `yaml:"fit,omitempty"`       // Fit method ("cover", "contain", "fill", "none")
 	Opacity   *float64         `yaml:"opacity,omitempty"`   // Image opacity (0.0-1.0)
+	Blend     *string          `yaml:"blend,omitempty"`     // Blend space ("srgb", "linear")
 }
 
 // MainOverlayConfig represents complete overlay configuration (runtime use)
 type MainOverlayConfig struct {
+	ID        string          `yaml:"id"`        // Identifier used to merge overlays across configuration levels
 	Visible   bool            `yaml:"visible"`   // Whether to render this overlay
 	Image     *string         `yaml:"image"`     // Path to image file (nil if none)
 	Placement PlacementConfig `yaml:"placement"` // Image positioning
 	Fit       string          `yaml:"fit"`       // Fit method ("cover", "contain", "fill", "none")
 	Opacity   float64         `yaml:"opacity"`   // Image opacity (0.0-1.0)
+	Blend     string          `yaml:"blend"`     // Blend space ("srgb", "linear")
+	Z         int             `yaml:"z"`         // Stacking order (higher values are drawn on top)
+}
+
+// ShapeConfig represents a vector shape drawn as its own layer.
+// Rectangles, rounded rectangles, circles and ellipses are placed in the box given by
+// X, Y, Width and Height; lines and polygons use Points.
+type ShapeConfig struct {
+	ID          string       `yaml:"id"`           // Identifier used to merge shapes across configuration levels
+	Visible     bool         `yaml:"visible"`      // Whether to render this shape
+	Type        string       `yaml:"type"`         // Shape type ("rect", "rounded-rect", "circle", "ellipse", "line", "polygon")
+	X           int          `yaml:"x"`            // Left edge of the shape box
+	Y           int          `yaml:"y"`            // Top edge of the shape box
+	Width       int          `yaml:"width"`        // Width of the shape box
+	Height      int          `yaml:"height"`       // Height of the shape box
+	Radius      float64      `yaml:"radius"`       // Corner radius for rounded rectangles
+	Points      []ShapePoint `yaml:"points"`       // Vertices for lines and polygons
+	Fill        string       `yaml:"fill"`         // Fill color (hex, empty for no fill)
+	Stroke      string       `yaml:"stroke"`       // Stroke color (hex, empty for no stroke)
+	StrokeWidth float64      `yaml:"stroke_width"` // Stroke width in pixels
+	Opacity     float64      `yaml:"opacity"`      // Shape opacity (0.0-1.0)
+	Z           int          `yaml:"z"`            // Stacking order (higher values are drawn on top)
+}
+
+// ShapePoint represents a vertex of a line or polygon shape.
+type ShapePoint struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+}
+
+// TagsConfig represents the tag chips element rendered from the article tags.
+// Each tag is drawn as a rounded chip; chips flow into rows inside the area.
+type TagsConfig struct {
+	Visible       bool                      `yaml:"visible"`        // Whether to render tag chips
+	Font          *string                   `yaml:"font"`           // Path to font file (nil means title font)
+	Size          float64                   `yaml:"size"`           // Font size
+	Weight        int                       `yaml:"weight"`         // Font weight (100-900, 400 is regular)
+	Color         string                    `yaml:"color"`          // Default chip text color (hex)
+	Background    string                    `yaml:"background"`     // Default chip background color (hex)
+	Colors        map[string]TagColorConfig `yaml:"colors"`         // Per-tag color mappings
+	Area          TextArea                  `yaml:"area"`           // Area the chips flow into
+	LineAlignment string                    `yaml:"line_alignment"` // Row alignment ("left", "center", "right")
+	PaddingX      int                       `yaml:"padding_x"`      // Horizontal padding inside each chip
+	PaddingY      int                       `yaml:"padding_y"`      // Vertical padding inside each chip
+	Radius        float64                   `yaml:"radius"`         // Corner radius (clamped to half the chip height)
+	Gap           int                       `yaml:"gap"`            // Spacing between chips and rows
+	Max           int                       `yaml:"max"`            // Maximum number of chips (0 means unlimited)
+	ShowMore      bool                      `yaml:"show_more"`      // Whether to add a "+N" chip for omitted tags
+	Z             int                       `yaml:"z"`              // Stacking order (higher values are drawn on top)
+}
+
+// TagColorConfig represents the colors of a single tag chip.
+// Empty values fall back to the default chip colors.
+type TagColorConfig struct {
+	Color      string `yaml:"color"`      // Text color (hex)
+	Background string `yaml:"background"` // Background color (hex)
 }
 
 // ArticleOverlayConfig represents overlay configuration in front matter.
 type ArticleOverlayConfig struct {
+	ID        string             `yaml:"id,omitempty"`        // Identifier of the overlay to override (overlays list only)
 	Visible   *bool              `yaml:"visible,omitempty"`   // Whether to render this overlay (default: true)
 	Image     *string            `yaml:"image,omitempty"`     // Path to image file
 	Placement *PlacementSettings `yaml:"placement,omitempty"` // Image positioning
 	Fit       *string            `yaml:"fit,omitempty"`       // Fit method ("cover", "contain", "fill", "none")
 	Opacity   *float64           `yaml:"opacity,omitempty"`   // Image opacity (0.0-1.0)
+	Blend     *string            `yaml:"blend,omitempty"`     // Blend space ("srgb", "linear")
+	Z         *int               `yaml:"z,omitempty"`         // Stacking order (higher values are drawn on top)
 }
 
 // BackgroundOverride represents background configuration overrides in front matter.
 type BackgroundOverride struct {
-	Image *string `yaml:"image,omitempty"` // Path to background image (relative to article directory)
-	Color *string `yaml:"color,omitempty"` // Background color (hex)
+	Source     *string              `yaml:"source,omitempty"`      // Background source ("config" or "cover")
+	Cover      *CoverSourceSettings `yaml:"cover,omitempty"`       // Cover image lookup overrides
+	Image      *string              `yaml:"image,omitempty"`       // Path to background image (relative to article directory)
+	Color      *string              `yaml:"color,omitempty"`       // Background color (hex)
+	Fit        *string              `yaml:"fit,omitempty"`         // Fit method ("cover", "contain", "fill", "none")
+	FocalPoint *FocalPointSettings  `yaml:"focal_point,omitempty"` // Focal point overrides
+	Filters    []BackgroundFilter   `yaml:"filters,omitempty"`     // Filter chain (replaces inherited filters)
+	Generative *GenerativeSettings  `yaml:"generative,omitempty"`  // Generative background overrides
+	Gradient   *GradientSettings    `yaml:"gradient,omitempty"`    // Gradient overrides
+	Pattern    *PatternSettings     `yaml:"pattern,omitempty"`     // Pattern overrides
+	Z          *int                 `yaml:"z,omitempty"`           // Stacking order (higher values are drawn on top)
 }
 
 // OutputOverride represents output configuration overrides in front matter.
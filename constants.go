@@ -41,6 +41,12 @@ const (
 	// DefaultDescriptionMinSize minimum description font size
 	DefaultDescriptionMinSize = 16.0
 
+	// DefaultTitleMaxSize maximum title font size for fit mode
+	DefaultTitleMaxSize = 128.0
+
+	// DefaultDescriptionMaxSize maximum description font size for fit mode
+	DefaultDescriptionMaxSize = 48.0
+
 	// DefaultLineHeight multiplier for line spacing
 	DefaultLineHeight = 1.2
 
@@ -50,8 +56,44 @@ const (
 	// DefaultDescriptionLetterSpacing for description text
 	DefaultDescriptionLetterSpacing = 0
 
-	// FontSizeShrinkFactor for iterative font size reduction
-	FontSizeShrinkFactor = 0.9
+	// FontSizeSearchPrecision font size precision of the shrink-to-fit search
+	FontSizeSearchPrecision = 0.5
+
+	// FontSizeSearchMaxIterations upper bound for the shrink-to-fit search
+	FontSizeSearchMaxIterations = 32
+)
+
+// Font style constants
+const (
+	// DefaultFontWeight regular font weight on the CSS scale
+	DefaultFontWeight = 400
+
+	// BoldFontWeight bold font weight on the CSS scale
+	BoldFontWeight = 700
+
+	// MinFontWeight lightest supported font weight
+	MinFontWeight = 100
+
+	// MaxFontWeight heaviest supported font weight
+	MaxFontWeight = 900
+
+	// DefaultFontWidth normal font width in percent
+	DefaultFontWidth = 100.0
+
+	// MinFontWidth narrowest supported font width in percent
+	MinFontWidth = 50.0
+
+	// MaxFontWidth widest supported font width in percent
+	MaxFontWidth = 200.0
+
+	// DefaultKerning whether kerning pairs are applied by default
+	DefaultKerning = true
+
+	// SyntheticBoldStrength extra stroke width per font size pixel for synthetic bold (weight 700)
+	SyntheticBoldStrength = 1.0 / 24.0
+
+	// SyntheticObliqueSkew horizontal skew factor for synthetic italic (about 12 degrees)
+	SyntheticObliqueSkew = 0.2
 )
 
 // Default area dimensions
@@ -124,6 +166,27 @@ const (
 
 	// OverflowClip clips text that doesn't fit
 	OverflowClip = "clip"
+
+	// OverflowEllipsis truncates text that doesn't fit and appends an ellipsis
+	OverflowEllipsis = "ellipsis"
+
+	// OverflowShrinkEllipsis shrinks text to fit and truncates it with an ellipsis at the minimum size
+	OverflowShrinkEllipsis = "shrink-then-ellipsis"
+
+	// OverflowFit grows or shrinks text to fill the area
+	OverflowFit = "fit"
+
+	// DefaultEllipsis horizontal ellipsis appended to truncated text
+	DefaultEllipsis = "…"
+)
+
+// Line wrapping constants
+const (
+	// WrapGreedy fills each line as far as it fits
+	WrapGreedy = "greedy"
+
+	// WrapBalanced breaks lines to even out their widths without adding lines
+	WrapBalanced = "balanced"
 )
 
 // Output format constants
@@ -147,6 +210,303 @@ const (
 	StaticDirectory = "static"
 )
 
+// Background gradient and pattern types
+const (
+	// GradientLinear blends colors along a straight line
+	GradientLinear = "linear"
+
+	// GradientRadial blends colors outwards from the center
+	GradientRadial = "radial"
+
+	// GradientConic blends colors around the center
+	GradientConic = "conic"
+
+	// PatternDots draws a grid of dots
+	PatternDots = "dots"
+
+	// PatternStripes draws parallel stripes
+	PatternStripes = "stripes"
+
+	// PatternGrid draws horizontal and vertical lines
+	PatternGrid = "grid"
+)
+
+// Text fill areas
+const (
+	// TextFillRelativeText spans the fill over the laid out text block
+	TextFillRelativeText = "text"
+
+	// TextFillRelativeCanvas spans the fill over the whole image, so that texts share one fill
+	TextFillRelativeCanvas = "canvas"
+)
+
+// Default text effect constants
+const (
+	// DefaultTextStrokeColor black text outline
+	DefaultTextStrokeColor = "#000000"
+
+	// DefaultTextShadowColor translucent black drop shadow
+	DefaultTextShadowColor = "#00000080"
+
+	// DefaultTextGlowColor translucent black glow, which keeps light text readable
+	DefaultTextGlowColor = "#000000A0"
+
+	// DefaultLineBackgroundPaddingX horizontal padding around each line of a line background
+	DefaultLineBackgroundPaddingX = 12
+
+	// DefaultLineBackgroundPaddingY vertical padding around each line of a line background
+	DefaultLineBackgroundPaddingY = 4
+
+	// DefaultLineBackgroundOpacity fully opaque line backgrounds
+	DefaultLineBackgroundOpacity = 1.0
+
+	// DefaultPanelPaddingX horizontal padding between a text panel and its text
+	DefaultPanelPaddingX = 32
+
+	// DefaultPanelPaddingY vertical padding between a text panel and its text
+	DefaultPanelPaddingY = 24
+
+	// DefaultPanelRadius corner radius of text panels
+	DefaultPanelRadius = 16.0
+
+	// DefaultPanelBorderColor subtle translucent black panel border
+	DefaultPanelBorderColor = "#00000033"
+
+	// DefaultMarkupCodeBackground subtle translucent black box behind code runs
+	DefaultMarkupCodeBackground = "#0000001A"
+
+	// DefaultMarkupHighlightBackground marker yellow box behind highlighted runs
+	DefaultMarkupHighlightBackground = "#FFE066"
+
+	// MarkupBackgroundPadding horizontal padding of run backgrounds relative to the font size
+	MarkupBackgroundPadding = 0.15
+
+	// MarkupBackgroundRadius corner radius of run backgrounds relative to the font size
+	MarkupBackgroundRadius = 0.2
+)
+
+// Background sources
+const (
+	// BackgroundSourceConfig uses the configured image, gradient or color
+	BackgroundSourceConfig = "config"
+
+	// BackgroundSourceCover uses the cover image of the article when one is found
+	BackgroundSourceCover = "cover"
+)
+
+// Default cover image lookup, following common Hugo theme conventions
+var (
+	// DefaultCoverFields front matter fields checked for a cover image
+	DefaultCoverFields = []string{"images", "cover.image", "image", "featured_image"}
+
+	// DefaultCoverGlobs page bundle files checked for a cover image
+	DefaultCoverGlobs = []string{"featured.*", "cover.*", "*feature*", "*cover*", "*thumbnail*"}
+)
+
+// Background fit methods and filter types
+const (
+	// BackgroundFitCover scales the image to fill the canvas and crops around the focal point
+	BackgroundFitCover = "cover"
+
+	// BackgroundFitContain scales the image to fit within the canvas over the background color
+	BackgroundFitContain = "contain"
+
+	// BackgroundFitFill stretches the image to the canvas size
+	BackgroundFitFill = "fill"
+
+	// BackgroundFitNone uses the image at its own size
+	BackgroundFitNone = "none"
+
+	// FilterBlur applies a gaussian blur
+	FilterBlur = "blur"
+
+	// FilterDarken blends colors towards black
+	FilterDarken = "darken"
+
+	// FilterBrighten blends colors towards white
+	FilterBrighten = "brighten"
+
+	// FilterGrayscale removes color saturation
+	FilterGrayscale = "grayscale"
+
+	// FilterDuotone maps luminance to a gradient between two colors
+	FilterDuotone = "duotone"
+
+	// FilterTint blends a color over the image
+	FilterTint = "tint"
+)
+
+// Default background image constants
+const (
+	// DefaultBackgroundSource uses the configured background
+	DefaultBackgroundSource = BackgroundSourceConfig
+
+	// DefaultBackgroundFit keeps background images at their own size
+	DefaultBackgroundFit = BackgroundFitNone
+
+	// DefaultFocalPoint centers cover crops
+	DefaultFocalPoint = 0.5
+
+	// DefaultBlurRadius blur radius in pixels
+	DefaultBlurRadius = 8.0
+
+	// DefaultDarkenAmount darken strength
+	DefaultDarkenAmount = 0.4
+
+	// DefaultBrightenAmount brighten strength
+	DefaultBrightenAmount = 0.3
+
+	// DefaultGrayscaleAmount grayscale strength
+	DefaultGrayscaleAmount = 1.0
+
+	// DefaultDuotoneShadow duotone color for dark tones
+	DefaultDuotoneShadow = "#000000"
+
+	// DefaultDuotoneHighlight duotone color for light tones
+	DefaultDuotoneHighlight = "#FFFFFF"
+
+	// DefaultTintColor tint color
+	DefaultTintColor = "#000000"
+
+	// DefaultTintOpacity tint opacity
+	DefaultTintOpacity = 0.5
+)
+
+// Generative background types
+const (
+	// GenerativeMesh blends palette colors between randomly placed points
+	GenerativeMesh = "mesh"
+
+	// GenerativeBlobs draws soft overlapping circles
+	GenerativeBlobs = "blobs"
+
+	// GenerativeTiles fills a grid with geometric tiles
+	GenerativeTiles = "tiles"
+)
+
+// Default generative background constants
+const (
+	// DefaultMeshPoints number of mesh gradient points
+	DefaultMeshPoints = 5
+
+	// DefaultBlobCount number of blobs
+	DefaultBlobCount = 7
+
+	// DefaultTileRows number of tile rows
+	DefaultTileRows = 6
+)
+
+// DefaultGenerativePalette colors used by generative backgrounds
+var DefaultGenerativePalette = []string{"#264653", "#2A9D8F", "#E9C46A", "#F4A261", "#E76F51"}
+
+// Default background gradient and pattern constants
+const (
+	// DefaultGradientAngle draws linear gradients from top to bottom
+	DefaultGradientAngle = 180.0
+
+	// DefaultGradientCenter places radial and conic gradients at the image center
+	DefaultGradientCenter = 0.5
+
+	// DefaultGradientDither whether gradients are dithered by default
+	DefaultGradientDither = true
+
+	// DefaultPatternColor translucent black pattern color
+	DefaultPatternColor = "#00000020"
+
+	// DefaultPatternSize distance between pattern elements in pixels
+	DefaultPatternSize = 24
+
+	// DefaultPatternThickness dot diameter or line width in pixels
+	DefaultPatternThickness = 2.0
+
+	// DefaultPatternAngle stripe angle in degrees
+	DefaultPatternAngle = 45.0
+)
+
+// Shape types
+const (
+	// ShapeRect draws a rectangle
+	ShapeRect = "rect"
+
+	// ShapeRoundedRect draws a rectangle with rounded corners
+	ShapeRoundedRect = "rounded-rect"
+
+	// ShapeCircle draws the largest circle centered in the shape box
+	ShapeCircle = "circle"
+
+	// ShapeEllipse draws an ellipse filling the shape box
+	ShapeEllipse = "ellipse"
+
+	// ShapeLine draws a polyline through the shape points
+	ShapeLine = "line"
+
+	// ShapePolygon draws a closed polygon through the shape points
+	ShapePolygon = "polygon"
+)
+
+// Default shape configuration constants
+const (
+	// DefaultShapeFill default shape fill color
+	DefaultShapeFill = "#000000"
+
+	// DefaultShapeStrokeWidth default shape stroke width in pixels
+	DefaultShapeStrokeWidth = 2.0
+
+	// DefaultShapeOpacity default shape opacity
+	DefaultShapeOpacity = 1.0
+
+	// DefaultShapeZ default stacking order for shapes
+	DefaultShapeZ = 0
+)
+
+// Default tag chips configuration constants
+const (
+	// DefaultTagsVisible whether tag chips are shown by default
+	DefaultTagsVisible = false
+
+	// DefaultTagFontSize for tag chip text
+	DefaultTagFontSize = 24.0
+
+	// DefaultTagColor white tag chip text
+	DefaultTagColor = "#FFFFFF"
+
+	// DefaultTagBackground dark gray tag chip background
+	DefaultTagBackground = "#555555"
+
+	// DefaultTagsAreaX starting X position for the tags area
+	DefaultTagsAreaX = 100
+
+	// DefaultTagsAreaY starting Y position for the tags area
+	DefaultTagsAreaY = 560
+
+	// DefaultTagsAreaWidth width of the tags area
+	DefaultTagsAreaWidth = 1000
+
+	// DefaultTagsAreaHeight height of the tags area
+	DefaultTagsAreaHeight = 50
+
+	// DefaultTagsLineAlignment for tag chip rows
+	DefaultTagsLineAlignment = "left"
+
+	// DefaultTagPaddingX horizontal padding inside tag chips
+	DefaultTagPaddingX = 16
+
+	// DefaultTagPaddingY vertical padding inside tag chips
+	DefaultTagPaddingY = 6
+
+	// DefaultTagRadius corner radius of tag chips; large values produce pill-shaped chips
+	DefaultTagRadius = 100.0
+
+	// DefaultTagGap spacing between tag chips
+	DefaultTagGap = 10
+
+	// DefaultTagsShowMore whether to show a "+N" chip for omitted tags
+	DefaultTagsShowMore = true
+
+	// TagMoreFormat format of the chip that counts omitted tags
+	TagMoreFormat = "+%d"
+)
+
 // Default overlay configuration constants
 const (
 	// DefaultOverlayVisible whether overlay is shown by default
@@ -163,6 +523,27 @@ const (
 
 	// DefaultOverlayY default Y position for overlay
 	DefaultOverlayY = 50
+
+	// DefaultOverlayBlend default color space for overlay blending
+	DefaultOverlayBlend = BlendSRGB
+
+	// DefaultOverlayZ default stacking order for overlays
+	DefaultOverlayZ = 0
+
+	// DefaultBackgroundZ default stacking order for the background layer
+	DefaultBackgroundZ = 0
+
+	// DefaultTextZ default stacking order for text layers
+	DefaultTextZ = 0
+)
+
+// Blend space constants
+const (
+	// BlendSRGB blends gamma-encoded sRGB values (fast, matches most image editors)
+	BlendSRGB = "srgb"
+
+	// BlendLinear blends in linear light for accurate anti-aliased edges
+	BlendLinear = "linear"
 )
 
 // Japanese line breaking character sets
@@ -178,6 +559,12 @@ const (
 const (
 	// DefaultFontCacheKey for embedded default font
 	DefaultFontCacheKey = "__default_embedded_font__"
+
+	// EmbeddedFontName describes the fallback font compiled into the binary
+	EmbeddedFontName = "Go Regular (embedded)"
+
+	// FontPackDirEnv environment variable pointing to a companion font pack directory
+	FontPackDirEnv = "OGP_FONT_DIR"
 )
 
 // Test border dimensions
@@ -1,19 +1,23 @@
 package main
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
 )
 
 // FontManager handles font loading with caching for improved performance.
 // It implements the FontLoader interface.
 type FontManager struct {
-	cache        map[string]*truetype.Font
-	pathResolver AssetPathResolver
+	cache             map[string]*truetype.Font
+	pathResolver      AssetPathResolver
+	systemFontPaths   []string // System font candidates in priority order
+	defaultFontSource string   // Where the default font was loaded from (empty until loaded)
 }
 
 // Verify that FontManager implements FontLoader interface
@@ -22,14 +26,15 @@ var _ FontLoader = (*FontManager)(nil)
 // NewFontManager creates a new FontManager with an empty cache.
 func NewFontManager(configDir string) *FontManager {
 	return &FontManager{
-		cache:        make(map[string]*truetype.Font),
-		pathResolver: NewPathResolver(configDir),
+		cache:           make(map[string]*truetype.Font),
+		pathResolver:    NewPathResolver(configDir),
+		systemFontPaths: systemFontCandidates(),
 	}
 }
 
 // LoadFont loads a font from the filesystem with caching.
 // It resolves the font path relative to config or article directories.
-// If fontPath is empty, it uses the embedded Go regular font as default.
+// If fontPath is empty, it uses the default font (font pack, system font or embedded Go Regular).
 func (fm *FontManager) LoadFont(fontPath string, articlePath string) (*truetype.Font, error) {
 	// Handle empty font path by using embedded default font
 	if strings.TrimSpace(fontPath) == "" {
@@ -52,6 +57,12 @@ func (fm *FontManager) LoadFont(fontPath string, articlePath string) (*truetype.
 		return nil, NewFontError("parse", resolvedPath, err)
 	}
 
+	if isVariableFont(fontBytes) {
+		// The TrueType rasterizer cannot apply variation axes, so only the
+		// default instance is available and styles are synthesized from it.
+		DefaultLogger.Info("Font %s is a variable font; weight, width and italic are synthesized from its default instance", resolvedPath)
+	}
+
 	fm.cache[resolvedPath] = font
 	return font, nil
 }
@@ -61,7 +72,9 @@ func (fm *FontManager) resolveFontPath(fontPath string, articlePath string) stri
 	return fm.pathResolver.ResolveAssetPath(fontPath, articlePath)
 }
 
-// getDefaultFont returns a system font with caching.
+// getDefaultFont returns the default font with caching.
+// It prefers a font from the font pack directory, then a system font,
+// and finally falls back to the Go Regular font embedded in the binary.
 func (fm *FontManager) getDefaultFont() (*truetype.Font, error) {
 	const defaultFontKey = DefaultFontCacheKey
 
@@ -69,27 +82,99 @@ func (fm *FontManager) getDefaultFont() (*truetype.Font, error) {
 		return font, nil
 	}
 
-	fontPath := fm.findSystemFont()
-	if fontPath == "" {
-		return nil, NewFontError("load", "system font", fmt.Errorf("no suitable system font found"))
-	}
+	candidates := append(fm.findFontPackFonts(), fm.findSystemFont())
+	for _, fontPath := range candidates {
+		if fontPath == "" {
+			continue
+		}
 
-	fontBytes, err := os.ReadFile(fontPath)
-	if err != nil {
-		return nil, NewFileError("read", fontPath, err)
+		fontBytes, err := os.ReadFile(fontPath)
+		if err != nil {
+			DefaultLogger.Warning("Failed to read default font candidate %s: %v", fontPath, err)
+			continue
+		}
+
+		font, err := truetype.Parse(fontBytes)
+		if err != nil {
+			DefaultLogger.Warning("Failed to parse default font candidate %s: %v", fontPath, err)
+			continue
+		}
+
+		fm.cache[defaultFontKey] = font
+		fm.defaultFontSource = fontPath
+		return font, nil
 	}
 
-	font, err := truetype.Parse(fontBytes)
+	font, err := truetype.Parse(goregular.TTF)
 	if err != nil {
-		return nil, NewFontError("parse", fontPath, err)
+		return nil, NewFontError("parse", EmbeddedFontName, err)
 	}
 
 	fm.cache[defaultFontKey] = font
+	fm.defaultFontSource = EmbeddedFontName
 	return font, nil
 }
 
+// DefaultFontSource returns a description of where the default font comes from.
+// It loads the default font if it has not been loaded yet.
+func (fm *FontManager) DefaultFontSource() string {
+	if fm.defaultFontSource == "" {
+		if _, err := fm.getDefaultFont(); err != nil {
+			return "unavailable"
+		}
+	}
+	return fm.defaultFontSource
+}
+
+// findFontPackFonts lists TrueType font files in the companion font pack directory.
+// The directory is taken from the OGP_FONT_DIR environment variable, which allows
+// shipping CJK fonts next to the binary in environments without system fonts.
+// Font collections and CFF-based OpenType fonts cannot be parsed, so only .ttf files are listed.
+func (fm *FontManager) findFontPackFonts() []string {
+	fontDir := os.Getenv(FontPackDirEnv)
+	if fontDir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(fontDir)
+	if err != nil {
+		DefaultLogger.Warning("Failed to read font pack directory %s: %v", fontDir, err)
+		return nil
+	}
+
+	var fontPaths []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if strings.ToLower(filepath.Ext(entry.Name())) != ".ttf" {
+			continue
+		}
+		fontPath := filepath.Join(fontDir, entry.Name())
+		if fm.isValidFont(fontPath) {
+			fontPaths = append(fontPaths, fontPath)
+		}
+	}
+
+	// Sort for deterministic selection across file systems
+	sort.Strings(fontPaths)
+	return fontPaths
+}
+
 // findSystemFont tries to find a suitable system font by checking common paths.
 func (fm *FontManager) findSystemFont() string {
+	// Check each path until we find a valid font
+	for _, fontPath := range fm.systemFontPaths {
+		if fm.isValidFont(fontPath) {
+			return fontPath
+		}
+	}
+
+	return ""
+}
+
+// systemFontCandidates returns OS-specific font paths in priority order.
+func systemFontCandidates() []string {
 	// OS-specific font paths in priority order
 	var fontPaths []string
 
@@ -141,14 +226,7 @@ func (fm *FontManager) findSystemFont() string {
 		}
 	}
 
-	// Check each path until we find a valid font
-	for _, fontPath := range fontPaths {
-		if fm.isValidFont(fontPath) {
-			return fontPath
-		}
-	}
-
-	return ""
+	return fontPaths
 }
 
 // isValidFont checks if a font file exists and is readable.
@@ -176,3 +254,25 @@ func (fm *FontManager) LoadFontWithFallback(fontPath string, articlePath string,
 	}
 	return font
 }
+
+// isVariableFont reports whether the font data contains an OpenType 'fvar' table.
+// Only single fonts are inspected; font collections always report false.
+func isVariableFont(fontBytes []byte) bool {
+	const tableRecordSize = 16
+	if len(fontBytes) < 12 || string(fontBytes[:4]) == "ttcf" {
+		return false
+	}
+
+	numTables := int(fontBytes[4])<<8 | int(fontBytes[5])
+	for i := 0; i < numTables; i++ {
+		offset := 12 + i*tableRecordSize
+		if offset+4 > len(fontBytes) {
+			return false
+		}
+		if string(fontBytes[offset:offset+4]) == "fvar" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -326,3 +326,84 @@ func TestFontManager_LoadFont_EmptyPath_UsesDefault(t *testing.T) {
 		t.Error("LoadFont should return the same cached default font for empty paths")
 	}
 }
+
+func TestFontManager_getDefaultFont_EmbeddedFallback(t *testing.T) {
+	t.Setenv(FontPackDirEnv, "")
+
+	fm := NewFontManager("/test/config")
+	// Simulate a minimal container without any system fonts
+	fm.systemFontPaths = nil
+
+	font, err := fm.LoadFont("", "/test/article")
+	if err != nil {
+		t.Fatalf("LoadFont should fall back to the embedded font: %v", err)
+	}
+	if font == nil {
+		t.Fatal("LoadFont should return the embedded font")
+	}
+
+	if fm.DefaultFontSource() != EmbeddedFontName {
+		t.Errorf("Expected embedded font to be used, got source %q", fm.DefaultFontSource())
+	}
+	if fm.DefaultFontSource() != EmbeddedFontName {
+		t.Errorf("Expected source %q, got %q", EmbeddedFontName, fm.DefaultFontSource())
+	}
+}
+
+func TestFontManager_getDefaultFont_FontPackDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	fontPath := filepath.Join(tempDir, "pack.ttf")
+	if err := os.WriteFile(fontPath, goregular.TTF, 0644); err != nil {
+		t.Fatalf("Failed to create font pack file: %v", err)
+	}
+	// Non-font files are ignored
+	if err := os.WriteFile(filepath.Join(tempDir, "README.txt"), []byte("fonts"), 0644); err != nil {
+		t.Fatalf("Failed to create readme: %v", err)
+	}
+	// Collections and OpenType fonts are ignored even when they sort first
+	for _, name := range []string{"a.otf", "a.ttc"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), goregular.TTF, 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	t.Setenv(FontPackDirEnv, tempDir)
+
+	fm := NewFontManager("/test/config")
+	fm.systemFontPaths = nil
+
+	if _, err := fm.LoadFont("", ""); err != nil {
+		t.Fatalf("LoadFont should load the font pack font: %v", err)
+	}
+
+	if fm.DefaultFontSource() != fontPath {
+		t.Errorf("Expected font pack font %q, got %q", fontPath, fm.DefaultFontSource())
+	}
+	if fm.DefaultFontSource() == EmbeddedFontName {
+		t.Error("Embedded font should not be used when a font pack font is available")
+	}
+}
+
+func TestFontManager_getDefaultFont_SkipsInvalidCandidates(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Large enough to pass the size check but not a valid font
+	invalidPath := filepath.Join(tempDir, "broken.ttf")
+	if err := os.WriteFile(invalidPath, make([]byte, 2048), 0644); err != nil {
+		t.Fatalf("Failed to create invalid font: %v", err)
+	}
+
+	t.Setenv(FontPackDirEnv, tempDir)
+
+	fm := NewFontManager("/test/config")
+	fm.systemFontPaths = nil
+
+	font, err := fm.LoadFont("", "")
+	if err != nil || font == nil {
+		t.Fatalf("LoadFont should skip invalid candidates and use the embedded font: %v", err)
+	}
+	if fm.DefaultFontSource() != EmbeddedFontName {
+		t.Errorf("Expected embedded font, got %q", fm.DefaultFontSource())
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// FontStyle describes the weight, width and slant requested for a text element.
+// Styles that the font file cannot provide are synthesized from the regular glyphs.
+type FontStyle struct {
+	Weight int     // Weight on the CSS scale (100-900, 400 is regular)
+	Width  float64 // Horizontal scale in percent (100 is normal)
+	Italic bool    // Whether to slant glyphs with an oblique skew
+}
+
+// textFontStyle extracts the font style from a text configuration.
+func textFontStyle(textConfig *TextConfig) FontStyle {
+	return FontStyle{
+		Weight: textConfig.Weight,
+		Width:  textConfig.Width,
+		Italic: textConfig.Italic,
+	}
+}
+
+// normalized returns the style with unset or out-of-range values replaced by defaults.
+func (s FontStyle) normalized() FontStyle {
+	if s.Weight <= 0 {
+		s.Weight = DefaultFontWeight
+	}
+	if s.Weight < MinFontWeight {
+		s.Weight = MinFontWeight
+	}
+	if s.Weight > MaxFontWeight {
+		s.Weight = MaxFontWeight
+	}
+	if s.Width <= 0 {
+		s.Width = DefaultFontWidth
+	}
+	if s.Width < MinFontWidth {
+		s.Width = MinFontWidth
+	}
+	if s.Width > MaxFontWidth {
+		s.Width = MaxFontWidth
+	}
+	return s
+}
+
+// isRegular reports whether the style needs no synthetic transformation.
+func (s FontStyle) isRegular() bool {
+	s = s.normalized()
+	return s.Weight <= DefaultFontWeight && s.Width == DefaultFontWidth && !s.Italic
+}
+
+// newTextFace creates a font face of the given size with the requested style applied.
+// Regular styles return the plain TrueType face.
+func newTextFace(f *truetype.Font, size float64, style FontStyle) font.Face {
+	face := truetype.NewFace(f, &truetype.Options{Size: size})
+	if style.isRegular() {
+		return face
+	}
+	return newSyntheticFace(face, size, style)
+}
+
+// warnUnavailableWeight warns when the style asks for a weight lighter than regular.
+// Only emboldening can be synthesized, so lighter weights use the regular glyphs.
+func warnUnavailableWeight(style FontStyle, element string) {
+	if weight := style.normalized().Weight; weight < DefaultFontWeight {
+		DefaultLogger.Warning("Weight %d of %s cannot be synthesized, using weight %d", weight, element, DefaultFontWeight)
+	}
+}
+
+// newTextConfigFace creates the face for a text element at the given size,
+// applying its font style and kerning setting. Glyph advances are cached.
+func newTextConfigFace(f *truetype.Font, size float64, textConfig *TextConfig) font.Face {
+	face := newTextFace(f, size, textFontStyle(textConfig))
+	if !textConfig.Kerning {
+		face = noKerningFace{face}
+	}
+	return newCachedFace(face)
+}
+
+// syntheticFace wraps a font face and applies synthetic emboldening,
+// horizontal scaling and oblique skew to every glyph it returns.
+// Like the TrueType face it wraps, it is not safe for concurrent use.
+type syntheticFace struct {
+	base      font.Face
+	scaleX    float64 // Horizontal scale factor
+	skew      float64 // Horizontal shift per pixel above the baseline
+	embolden  float64 // Extra stroke width in pixels added to each glyph
+	glyphs    map[rune]*syntheticGlyph
+	emboldenX fixed.Int26_6 // Extra advance caused by emboldening
+}
+
+// syntheticGlyph is a transformed glyph mask positioned relative to the dot.
+type syntheticGlyph struct {
+	mask    *image.Alpha
+	offset  image.Point // Top-left corner of the mask relative to the dot
+	advance fixed.Int26_6
+	ok      bool
+}
+
+// newSyntheticFace creates a face that synthesizes the given style from base.
+func newSyntheticFace(base font.Face, size float64, style FontStyle) *syntheticFace {
+	style = style.normalized()
+
+	sf := &syntheticFace{
+		base:   base,
+		scaleX: style.Width / DefaultFontWidth,
+		glyphs: make(map[rune]*syntheticGlyph),
+	}
+
+	if style.Italic {
+		sf.skew = SyntheticObliqueSkew
+	}
+
+	// Only emboldening is synthesized; lighter weights use the regular glyphs
+	if style.Weight > DefaultFontWeight {
+		boldSteps := float64(style.Weight-DefaultFontWeight) / float64(BoldFontWeight-DefaultFontWeight)
+		sf.embolden = size * SyntheticBoldStrength * boldSteps
+		sf.emboldenX = fixed.Int26_6(math.Round(sf.embolden * 64))
+	}
+
+	return sf
+}
+
+// Close implements font.Face.
+func (sf *syntheticFace) Close() error {
+	return sf.base.Close()
+}
+
+// Metrics implements font.Face.
+func (sf *syntheticFace) Metrics() font.Metrics {
+	return sf.base.Metrics()
+}
+
+// Kern implements font.Face.
+func (sf *syntheticFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return sf.scaleAdvance(sf.base.Kern(r0, r1))
+}
+
+// GlyphAdvance implements font.Face.
+func (sf *syntheticFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	advance, ok := sf.base.GlyphAdvance(r)
+	if !ok {
+		return 0, false
+	}
+	return sf.scaleAdvance(advance) + sf.emboldenX, true
+}
+
+// GlyphBounds implements font.Face.
+func (sf *syntheticFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	bounds, advance, ok := sf.base.GlyphBounds(r)
+	if !ok {
+		return bounds, 0, false
+	}
+
+	minX, maxX := float64(bounds.Min.X)*sf.scaleX, float64(bounds.Max.X)*sf.scaleX
+	// Skew shifts the top of the glyph (negative Y) to the right
+	minX -= sf.skew * float64(bounds.Max.Y)
+	maxX -= sf.skew * float64(bounds.Min.Y)
+	maxX += float64(sf.emboldenX)
+
+	result := fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: fixed.Int26_6(math.Floor(minX)), Y: bounds.Min.Y - sf.emboldenX},
+		Max: fixed.Point26_6{X: fixed.Int26_6(math.Ceil(maxX)), Y: bounds.Max.Y},
+	}
+	return result, sf.scaleAdvance(advance) + sf.emboldenX, true
+}
+
+// Glyph implements font.Face.
+func (sf *syntheticFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	glyph := sf.glyph(r)
+	if !glyph.ok {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+
+	origin := image.Point{X: dot.X.Round(), Y: dot.Y.Round()}
+	dr := glyph.mask.Bounds().Sub(glyph.mask.Bounds().Min).Add(origin.Add(glyph.offset))
+	return dr, glyph.mask, glyph.mask.Bounds().Min, glyph.advance, true
+}
+
+// glyph returns the cached transformed glyph for r, building it on first use.
+func (sf *syntheticFace) glyph(r rune) *syntheticGlyph {
+	if glyph, exists := sf.glyphs[r]; exists {
+		return glyph
+	}
+
+	glyph := &syntheticGlyph{}
+	dr, mask, maskp, advance, ok := sf.base.Glyph(fixed.Point26_6{}, r)
+	if ok {
+		glyph.ok = true
+		glyph.advance = sf.scaleAdvance(advance) + sf.emboldenX
+		glyph.mask, glyph.offset = sf.transformMask(dr, mask, maskp)
+	}
+
+	sf.glyphs[r] = glyph
+	return glyph
+}
+
+// transformMask applies width scaling, skew and emboldening to a glyph mask.
+// dr is the glyph rectangle relative to the dot at the origin.
+func (sf *syntheticFace) transformMask(dr image.Rectangle, mask image.Image, maskp image.Point) (*image.Alpha, image.Point) {
+	if dr.Empty() {
+		return image.NewAlpha(image.Rectangle{}), dr.Min
+	}
+
+	// Transformed bounds before emboldening
+	minX := math.Floor(math.Min(float64(dr.Min.X)*sf.scaleX-sf.skew*float64(dr.Max.Y), float64(dr.Min.X)*sf.scaleX-sf.skew*float64(dr.Min.Y)))
+	maxX := math.Ceil(math.Max(float64(dr.Max.X)*sf.scaleX-sf.skew*float64(dr.Min.Y), float64(dr.Max.X)*sf.scaleX-sf.skew*float64(dr.Max.Y)))
+	bold := int(math.Ceil(sf.embolden))
+
+	out := image.Rect(int(minX), dr.Min.Y-bold, int(maxX)+bold, dr.Max.Y)
+	transformed := image.NewAlpha(image.Rect(0, 0, out.Dx(), out.Dy()))
+
+	sample := func(x, y int) float64 {
+		p := image.Point{X: x, Y: y}
+		if !p.In(dr) {
+			return 0
+		}
+		_, _, _, a := mask.At(maskp.X+x-dr.Min.X, maskp.Y+y-dr.Min.Y).RGBA()
+		return float64(a) / 0xffff
+	}
+
+	for oy := out.Min.Y; oy < out.Max.Y; oy++ {
+		for ox := out.Min.X; ox < out.Max.X; ox++ {
+			// Inverse mapping from the output pixel center to the source glyph
+			sx := (float64(ox)+0.5+sf.skew*(float64(oy)+0.5))/sf.scaleX - 0.5
+			x0 := int(math.Floor(sx))
+			fx := sx - float64(x0)
+			value := sample(x0, oy)*(1-fx) + sample(x0+1, oy)*fx
+			transformed.SetAlpha(ox-out.Min.X, oy-out.Min.Y, alphaFromUnit(value))
+		}
+	}
+
+	if sf.embolden > 0 {
+		transformed = emboldenMask(transformed, sf.embolden)
+	}
+
+	return transformed, out.Min
+}
+
+// scaleAdvance applies the horizontal scale to an advance or kerning value.
+func (sf *syntheticFace) scaleAdvance(v fixed.Int26_6) fixed.Int26_6 {
+	return fixed.Int26_6(math.Round(float64(v) * sf.scaleX))
+}
+
+// emboldenMask thickens a glyph mask by strength pixels towards the right and top,
+// similar to FreeType's bitmap emboldening. Fractional strengths are blended.
+func emboldenMask(src *image.Alpha, strength float64) *image.Alpha {
+	bounds := src.Bounds()
+	dst := image.NewAlpha(bounds)
+	steps := int(math.Ceil(strength))
+
+	// Weight of each shift distance; the last step carries the fractional part
+	weights := make([]float64, steps+1)
+	for d := range weights {
+		weights[d] = math.Min(1, strength-float64(d)+1)
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			var value float64
+			for dy := 0; dy <= steps && y+dy < bounds.Max.Y; dy++ {
+				for dx := 0; dx <= steps && x-dx >= bounds.Min.X; dx++ {
+					weight := math.Min(weights[dx], weights[dy])
+					value = math.Max(value, float64(src.AlphaAt(x-dx, y+dy).A)*weight)
+				}
+			}
+			dst.SetAlpha(x, y, alphaFromUnit(value/255))
+		}
+	}
+
+	return dst
+}
+
+// alphaFromUnit converts a coverage value in the range 0.0-1.0 to an alpha color.
+func alphaFromUnit(v float64) color.Alpha {
+	if v <= 0 {
+		return color.Alpha{}
+	}
+	if v >= 1 {
+		return color.Alpha{A: 255}
+	}
+	return color.Alpha{A: uint8(math.Round(v * 255))}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func parseTestFont(t *testing.T) *truetype.Font {
+	t.Helper()
+	f, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
+	}
+	return f
+}
+
+func TestFontStyle_isRegular(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    FontStyle
+		expected bool
+	}{
+		{"zero value", FontStyle{}, true},
+		{"explicit regular", FontStyle{Weight: 400, Width: 100}, true},
+		{"light weight uses regular glyphs", FontStyle{Weight: 300, Width: 100}, true},
+		{"bold", FontStyle{Weight: 700}, false},
+		{"condensed", FontStyle{Width: 80}, false},
+		{"italic", FontStyle{Italic: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.style.isRegular(); got != tt.expected {
+				t.Errorf("isRegular() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFontStyle_normalized_Clamps(t *testing.T) {
+	style := FontStyle{Weight: 2000, Width: 10}.normalized()
+	if style.Weight != MaxFontWeight {
+		t.Errorf("Expected weight clamped to %d, got %d", MaxFontWeight, style.Weight)
+	}
+	if style.Width != MinFontWidth {
+		t.Errorf("Expected width clamped to %f, got %f", MinFontWidth, style.Width)
+	}
+}
+
+func TestNewTextFace_RegularReturnsTrueTypeFace(t *testing.T) {
+	face := newTextFace(parseTestFont(t), 32, FontStyle{})
+	if _, ok := face.(*syntheticFace); ok {
+		t.Error("Regular style should not use a synthetic face")
+	}
+}
+
+func TestSyntheticFace_Advances(t *testing.T) {
+	f := parseTestFont(t)
+	regular := font.MeasureString(newTextFace(f, 32, FontStyle{}), "Hello")
+	bold := font.MeasureString(newTextFace(f, 32, FontStyle{Weight: 700}), "Hello")
+	wide := font.MeasureString(newTextFace(f, 32, FontStyle{Width: 150}), "Hello")
+	italic := font.MeasureString(newTextFace(f, 32, FontStyle{Italic: true}), "Hello")
+
+	if bold <= regular {
+		t.Errorf("Bold text should be wider than regular: bold=%v regular=%v", bold, regular)
+	}
+	if wide < regular*14/10 || wide > regular*16/10 {
+		t.Errorf("150%% width should be about 1.5x regular: wide=%v regular=%v", wide, regular)
+	}
+	if italic != regular {
+		t.Errorf("Italic should keep regular advances: italic=%v regular=%v", italic, regular)
+	}
+}
+
+func TestSyntheticFace_BoldAddsInk(t *testing.T) {
+	f := parseTestFont(t)
+
+	countInk := func(style FontStyle) int {
+		img := image.NewRGBA(image.Rect(0, 0, 300, 80))
+		face := newTextFace(f, 40, style)
+		drawStringWithSpacing(img, image.NewUniform(color.Black), face, "Bold", 10, 50, 0)
+
+		ink := 0
+		for i := 3; i < len(img.Pix); i += 4 {
+			ink += int(img.Pix[i])
+		}
+		return ink
+	}
+
+	regular := countInk(FontStyle{})
+	bold := countInk(FontStyle{Weight: 800})
+	if bold <= regular {
+		t.Errorf("Synthetic bold should cover more pixels: bold=%d regular=%d", bold, regular)
+	}
+}
+
+func TestSyntheticFace_ItalicSkewsGlyph(t *testing.T) {
+	f := parseTestFont(t)
+	regularBounds, _, _ := newTextFace(f, 40, FontStyle{}).GlyphBounds('l')
+	italicBounds, _, _ := newTextFace(f, 40, FontStyle{Italic: true}).GlyphBounds('l')
+
+	if italicBounds.Max.X <= regularBounds.Max.X {
+		t.Errorf("Italic glyph top should lean right: italic=%v regular=%v", italicBounds, regularBounds)
+	}
+}
+
+func TestWarnUnavailableWeight(t *testing.T) {
+	if output := captureOutput(func() { warnUnavailableWeight(FontStyle{Weight: 300}, "title") }); !strings.Contains(output, "Weight 300 of title") {
+		t.Errorf("Expected a warning for a light weight, got %q", output)
+	}
+	for _, weight := range []int{0, 400, 700} {
+		if output := captureOutput(func() { warnUnavailableWeight(FontStyle{Weight: weight}, "title") }); output != "" {
+			t.Errorf("Expected no warning for weight %d, got %q", weight, output)
+		}
+	}
+}
+
+func TestIsVariableFont(t *testing.T) {
+	if isVariableFont(goregular.TTF) {
+		t.Error("Go Regular is a static font")
+	}
+
+	// Minimal table directory containing an fvar record
+	variable := make([]byte, 12+16)
+	variable[5] = 1
+	copy(variable[12:], "fvar")
+	if !isVariableFont(variable) {
+		t.Error("Font with fvar table should be detected as variable")
+	}
+
+	if isVariableFont([]byte("short")) {
+		t.Error("Truncated data should not be detected as variable")
+	}
+}
+
+func TestConfigMerger_FontStyleOverrides(t *testing.T) {
+	merger := NewConfigMerger()
+	defaultConfig := getDefaultConfig()
+
+	typeSettings := &ConfigSettings{
+		Title: &TextSettings{Weight: intPtr(700)},
+	}
+	ogpFM := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			Width:  float64Ptr(90),
+			Italic: boolPtr(true),
+		},
+	}
+
+	result := merger.MergeConfigsWithSettings(defaultConfig, nil, typeSettings, ogpFM)
+
+	if result.Title.Weight != 700 {
+		t.Errorf("Expected title weight 700, got %d", result.Title.Weight)
+	}
+	if result.Title.Width != 90 {
+		t.Errorf("Expected title width 90, got %f", result.Title.Width)
+	}
+	if !result.Title.Italic {
+		t.Error("Expected title to be italic")
+	}
+	if result.Description.Weight != DefaultFontWeight || result.Description.Italic {
+		t.Error("Description style should keep defaults")
+	}
+}
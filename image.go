@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
 	"image/jpeg"
 	"image/png"
 	"math"
@@ -83,36 +84,134 @@ func resizeImage(src image.Image, targetWidth, targetHeight int, fit string) ima
 	return imaging.Resize(src, dstWidth, dstHeight, imaging.Lanczos)
 }
 
-// compositeImage composites a source image onto a destination image at the specified position.
-// It supports alpha blending with the given opacity (0.0 to 1.0).
-func compositeImage(dst *image.RGBA, src image.Image, x, y int, opacity float64) {
+// resolveBlendSpace returns the blend space for a configured value, warning about
+// unknown values and falling back to sRGB blending for them.
+func resolveBlendSpace(blend string) string {
+	switch blend {
+	case BlendSRGB, BlendLinear:
+		return blend
+	}
+	DefaultLogger.Warning("Unknown overlay blend '%s', using %s", blend, BlendSRGB)
+	return BlendSRGB
+}
+
+// compositeImageWithBlend composites src onto dst at (x, y) using the given blend space.
+// BlendSRGB uses image/draw directly; BlendLinear converts to linear light before blending,
+// which gives more accurate anti-aliased and semi-transparent edges.
+func compositeImageWithBlend(dst *image.RGBA, src image.Image, x, y int, opacity float64, blend string) {
+	opacity = clampUnit(opacity)
+	if opacity == 0 {
+		return
+	}
+
 	srcBounds := src.Bounds()
-	dstBounds := dst.Bounds()
+	r := image.Rect(x, y, x+srcBounds.Dx(), y+srcBounds.Dy())
+
+	if blend == BlendLinear {
+		compositeLinear(dst, src, r, srcBounds.Min, opacity)
+		return
+	}
+
+	if opacity >= 1 {
+		draw.Draw(dst, r, src, srcBounds.Min, draw.Over)
+		return
+	}
 
-	for sy := srcBounds.Min.Y; sy < srcBounds.Max.Y; sy++ {
-		for sx := srcBounds.Min.X; sx < srcBounds.Max.X; sx++ {
-			dx := x + sx - srcBounds.Min.X
-			dy := y + sy - srcBounds.Min.Y
+	mask := image.NewUniform(color.Alpha{A: uint8(math.Round(opacity * 255))})
+	draw.DrawMask(dst, r, src, srcBounds.Min, mask, image.Point{}, draw.Over)
+}
+
+// compositeLinear blends src over dst in linear light with a uniform opacity.
+// r is the destination rectangle and sp the matching source point.
+func compositeLinear(dst *image.RGBA, src image.Image, r image.Rectangle, sp image.Point, opacity float64) {
+	clipped := r.Intersect(dst.Bounds())
+	if clipped.Empty() {
+		return
+	}
+	sp = sp.Add(clipped.Min.Sub(r.Min))
+
+	// Convert the source region to premultiplied RGBA once
+	srcRGBA := image.NewRGBA(image.Rect(0, 0, clipped.Dx(), clipped.Dy()))
+	draw.Draw(srcRGBA, srcRGBA.Bounds(), src, sp, draw.Src)
+
+	for y := 0; y < clipped.Dy(); y++ {
+		srcRow := srcRGBA.Pix[y*srcRGBA.Stride:]
+		dstRow := dst.Pix[dst.PixOffset(clipped.Min.X, clipped.Min.Y+y):]
 
-			if dx < dstBounds.Min.X || dx >= dstBounds.Max.X ||
-				dy < dstBounds.Min.Y || dy >= dstBounds.Max.Y {
+		for x := 0; x < clipped.Dx(); x++ {
+			i := x * 4
+			sa := float64(srcRow[i+3]) / 255 * opacity
+			if sa == 0 {
 				continue
 			}
+			da := float64(dstRow[i+3]) / 255
+			outA := sa + da*(1-sa)
+
+			for c := 0; c < 3; c++ {
+				srcLinear := unpremultipliedLinear(srcRow[i+c], srcRow[i+3])
+				dstLinear := unpremultipliedLinear(dstRow[i+c], dstRow[i+3])
+				blended := (srcLinear*sa + dstLinear*da*(1-sa)) / outA
+				dstRow[i+c] = uint8(math.Round(linearToSRGB(blended) * outA * 255))
+			}
+			dstRow[i+3] = uint8(math.Round(outA * 255))
+		}
+	}
+}
 
-			srcColor := color.RGBAModel.Convert(src.At(sx, sy)).(color.RGBA)
-			dstColor := color.RGBAModel.Convert(dst.At(dx, dy)).(color.RGBA)
-
-			alpha := float64(srcColor.A) * opacity / 255.0
-			invAlpha := 1.0 - alpha
+// srgbToLinearTable maps 8-bit sRGB values to linear light (0.0-1.0).
+var srgbToLinearTable = func() [256]float64 {
+	var table [256]float64
+	for i := range table {
+		c := float64(i) / 255
+		if c <= 0.04045 {
+			table[i] = c / 12.92
+		} else {
+			table[i] = math.Pow((c+0.055)/1.055, 2.4)
+		}
+	}
+	return table
+}()
 
-			blendedR := uint8(float64(srcColor.R)*alpha + float64(dstColor.R)*invAlpha)
-			blendedG := uint8(float64(srcColor.G)*alpha + float64(dstColor.G)*invAlpha)
-			blendedB := uint8(float64(srcColor.B)*alpha + float64(dstColor.B)*invAlpha)
-			blendedA := uint8(math.Max(float64(srcColor.A)*opacity, float64(dstColor.A)))
+// unpremultipliedLinear converts a premultiplied 8-bit channel to straight linear light.
+func unpremultipliedLinear(value, alpha uint8) float64 {
+	if alpha == 0 {
+		return 0
+	}
+	straight := int(math.Round(float64(value) * 255 / float64(alpha)))
+	if straight > 255 {
+		straight = 255
+	}
+	return srgbToLinearTable[straight]
+}
 
-			dst.Set(dx, dy, color.RGBA{R: blendedR, G: blendedG, B: blendedB, A: blendedA})
+// linearToSRGBTable maps linear light quantized to 12 bits to sRGB (0.0-1.0).
+var linearToSRGBTable = func() [4096]float64 {
+	var table [4096]float64
+	for i := range table {
+		c := float64(i) / 4095
+		if c <= 0.0031308 {
+			table[i] = c * 12.92
+		} else {
+			table[i] = 1.055*math.Pow(c, 1/2.4) - 0.055
 		}
 	}
+	return table
+}()
+
+// linearToSRGB converts linear light (0.0-1.0) to sRGB (0.0-1.0).
+func linearToSRGB(c float64) float64 {
+	return linearToSRGBTable[int(clampUnit(c)*4095+0.5)]
+}
+
+// clampUnit clamps v to the range 0.0-1.0.
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
 }
 
 // OverlaySettings defines the interface for overlay configuration
@@ -121,6 +220,7 @@ type OverlaySettings interface {
 	GetPlacement() *PlacementConfig
 	GetFit() *string
 	GetOpacity() *float64
+	GetBlend() *string
 }
 
 // compositeCustomImage composites an overlay image with full configuration support.
@@ -148,6 +248,7 @@ func compositeCustomImage(dst *image.RGBA, basePath string, overlaySettings Over
 	width, height := originalWidth, originalHeight
 	fit := "contain"
 	opacity := 1.0
+	blend := BlendSRGB
 
 	var widthSpecified, heightSpecified bool
 	placement := overlaySettings.GetPlacement()
@@ -177,13 +278,11 @@ func compositeCustomImage(dst *image.RGBA, basePath string, overlaySettings Over
 	}
 
 	if overlaySettings.GetOpacity() != nil {
-		opacity = *overlaySettings.GetOpacity()
-		if opacity < 0 {
-			opacity = 0
-		}
-		if opacity > 1 {
-			opacity = 1
-		}
+		opacity = clampUnit(*overlaySettings.GetOpacity())
+	}
+
+	if overlaySettings.GetBlend() != nil && *overlaySettings.GetBlend() != "" {
+		blend = resolveBlendSpace(*overlaySettings.GetBlend())
 	}
 
 	resizedImg := resizeImage(img, width, height, fit)
@@ -203,24 +302,14 @@ func compositeCustomImage(dst *image.RGBA, basePath string, overlaySettings Over
 			cropY := (resizedHeight - height) / 2
 
 			croppedImg := image.NewRGBA(image.Rect(0, 0, width, height))
-
-			for cy := 0; cy < height; cy++ {
-				for cx := 0; cx < width; cx++ {
-					srcX := cropX + cx
-					srcY := cropY + cy
-
-					if srcX < resizedBounds.Max.X && srcY < resizedBounds.Max.Y {
-						at := resizedImg.At(resizedBounds.Min.X+srcX, resizedBounds.Min.Y+srcY)
-						croppedImg.Set(cx, cy, at)
-					}
-				}
-			}
+			cropOrigin := resizedBounds.Min.Add(image.Point{X: cropX, Y: cropY})
+			draw.Draw(croppedImg, croppedImg.Bounds(), resizedImg, cropOrigin, draw.Src)
 
 			resizedImg = croppedImg
 		}
 	}
 
-	compositeImage(dst, resizedImg, x, y, opacity)
+	compositeImageWithBlend(dst, resizedImg, x, y, opacity, blend)
 
 	return nil
 }
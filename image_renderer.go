@@ -4,18 +4,23 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
+	"regexp"
 	"strings"
 
-	"github.com/golang/freetype"
 	"github.com/golang/freetype/truetype"
-	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
 )
 
 // ImageRenderer handles text rendering on images with Japanese line breaking support.
 // It manages font sizing, text positioning, and layout within defined areas.
 // It implements the ImageTextRenderer interface.
 type ImageRenderer struct {
-	// We'll create text processors on demand based on each text's configuration
+	// Text processors are created on demand based on each text's configuration
+	fontManager       *FontManager              // Loads fonts of styled runs from resolved paths
+	failedFonts       map[string]bool           // Font paths of styled runs that failed to load
+	monoFont          *truetype.Font            // Embedded Go Mono for code runs, parsed on first use
+	highlightPatterns map[string]*regexp.Regexp // Compiled highlight rules (nil for invalid rules)
 }
 
 // Verify that ImageRenderer implements ImageTextRenderer interface
@@ -23,7 +28,11 @@ var _ ImageTextRenderer = (*ImageRenderer)(nil)
 
 // NewImageRenderer creates a new ImageRenderer.
 func NewImageRenderer() *ImageRenderer {
-	return &ImageRenderer{}
+	return &ImageRenderer{
+		fontManager:       NewFontManager(""),
+		failedFonts:       make(map[string]bool),
+		highlightPatterns: make(map[string]*regexp.Regexp),
+	}
 }
 
 // RenderOptions contains all parameters needed for text rendering.
@@ -40,7 +49,7 @@ type RenderOptions struct {
 func (ir *ImageRenderer) RenderTextOnImage(dst *image.RGBA, options *RenderOptions) error {
 	// Render title if visible and provided
 	if options.Config.Title.Visible && options.Title != "" {
-		err := ir.renderSingleText(dst, options.Font, &options.Config.Title, options.Title, options.TestMode, "title")
+		err := ir.RenderTextElement(dst, options.Font, &options.Config.Title, options.Title, options.TestMode, "title")
 		if err != nil {
 			return fmt.Errorf("failed to render title: %w", err)
 		}
@@ -48,7 +57,7 @@ func (ir *ImageRenderer) RenderTextOnImage(dst *image.RGBA, options *RenderOptio
 
 	// Render description if visible and provided
 	if options.Config.Description.Visible && options.Description != "" {
-		err := ir.renderSingleText(dst, options.Font, &options.Config.Description, options.Description, options.TestMode, "description")
+		err := ir.RenderTextElement(dst, options.Font, &options.Config.Description, options.Description, options.TestMode, "description")
 		if err != nil {
 			return fmt.Errorf("failed to render description: %w", err)
 		}
@@ -57,8 +66,8 @@ func (ir *ImageRenderer) RenderTextOnImage(dst *image.RGBA, options *RenderOptio
 	return nil
 }
 
-// renderSingleText renders a single text element (title or description) onto the image.
-func (ir *ImageRenderer) renderSingleText(dst *image.RGBA, font *truetype.Font, textConfig *TextConfig, text string, testMode bool, textType string) error {
+// RenderTextElement renders a single text element (title or description) onto the image.
+func (ir *ImageRenderer) RenderTextElement(dst *image.RGBA, font *truetype.Font, textConfig *TextConfig, text string, testMode bool, textType string) error {
 	area := textConfig.Area
 	alignment := textConfig.BlockPosition
 	lineAlignment := textConfig.LineAlignment
@@ -69,6 +78,10 @@ func (ir *ImageRenderer) renderSingleText(dst *image.RGBA, font *truetype.Font,
 	if fontSize <= 0 || fontSize > 1000 {
 		return NewValidationError(fmt.Sprintf("invalid font size: %f (must be between 0 and 1000)", fontSize))
 	}
+	if maxFontSize := fitMaxFontSize(textConfig); maxFontSize > 1000 {
+		return NewValidationError(fmt.Sprintf("invalid max font size: %f (must be at most 1000)", maxFontSize))
+	}
+	warnUnavailableWeight(textFontStyle(textConfig), textType)
 
 	if area.X == 0 && area.Y == 0 && area.Width == 0 && area.Height == 0 {
 		bounds := dst.Bounds()
@@ -96,131 +109,186 @@ func (ir *ImageRenderer) renderSingleText(dst *image.RGBA, font *truetype.Font,
 		overflow = "shrink"
 	}
 
-	maxWidth := area.Width
+	// Stroke, shadow, glow and backgrounds extend beyond the glyphs, so the text is laid
+	// out in a smaller area that leaves room for them within the configured area
+	effects := hasTextEffects(textConfig)
+	lineBackground := textConfig.LineBackground.Color != ""
+	panel := hasTextPanel(&textConfig.Panel)
+	runBackgrounds := hasRunBackgrounds(textConfig, ir.textRuns(textConfig, text))
+	layoutArea := area
+	if effects || lineBackground || panel || runBackgrounds {
+		insets := effectInsets(textConfig)
+		var descent int
+		if lineBackground || panel {
+			// The descent scales with the font, so the largest size the text may take bounds it
+			descent = blockDescent(font, fitMaxFontSize(textConfig), textConfig)
+		}
+		if lineBackground {
+			insets = insets.union(lineBackgroundInsets(&textConfig.LineBackground, descent))
+		}
+		if panel {
+			insets = insets.union(panelInsets(&textConfig.Panel, descent))
+		}
+		if runBackgrounds {
+			// The padding scales with the font, so the largest size the text may take bounds it
+			insets = insets.union(runBackgroundInsets(fitMaxFontSize(textConfig)))
+		}
+		layoutArea = insets.inset(area)
+	}
+
+	maxWidth := layoutArea.Width
 
 	// Create text processor for this specific text configuration
 	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
 	textProcessor := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
-
-	c := freetype.NewContext()
-	c.SetFont(font)
-	c.SetFontSize(fontSize)
-	c.SetClip(dst.Bounds())
-	c.SetDst(dst)
+	textProcessor.wrap = textConfig.Wrap
 
 	textColor, err := parseHexColor(textConfig.Color)
 	if err != nil {
 		textColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
 		DefaultLogger.Warning("Failed to parse color '%s', using white: %v", textConfig.Color, err)
 	}
-	c.SetSrc(image.NewUniform(textColor))
 
-	face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-	lines := textProcessor.SplitText(text, face, maxWidth)
+	var layout *TextLayout
+	switch overflow {
+	case OverflowShrink, OverflowShrinkEllipsis, OverflowFit:
+		layout = ir.adjustFontSizeToFit(font, text, textConfig, layoutArea, textProcessor)
+	default:
+		layout = ir.layoutElement(font, text, fontSize, textConfig, maxWidth, textProcessor)
+	}
+
+	// Lines past max_lines are dropped; the ellipsis modes also drop lines past the area
+	// and mark the cut with the ellipsis
+	switch overflow {
+	case OverflowEllipsis, OverflowShrinkEllipsis:
+		layout.truncate(layout.visibleLines(layoutArea.Height, textConfig.MaxLines), textConfig.Ellipsis, maxWidth)
+	default:
+		if textConfig.MaxLines > 0 {
+			layout.truncate(textConfig.MaxLines, "", maxWidth)
+		}
+	}
+
+	blockX, blockY := calculateTextPosition(layoutArea, alignment, layout.Width, layout.Height)
+	block := image.Rect(blockX, blockY, blockX+layout.Width, blockY+layout.Height)
+	src := textFillSource(&textConfig.Fill, textColor, block, dst.Bounds())
 
-	if overflow == "shrink" {
-		fontSize, face, lines = ir.adjustFontSizeToFit(font, text, textConfig, area, maxWidth, lines, textProcessor)
-		c.SetFontSize(fontSize)
+	origins := layout.lineOrigins(layoutArea, alignment, lineAlignment)
+	if panel {
+		drawTextPanel(dst, textInkBlock(layout, block, origins), &textConfig.Panel)
+	}
+	if lineBackground {
+		drawLineBackgrounds(dst, layout, origins, &textConfig.LineBackground)
+	}
+	if layout.Runs != nil {
+		drawRunBackgrounds(dst, layout, origins, textConfig)
 	}
 
-	ir.renderTextLines(c, face, lines, textConfig, area, alignment, lineAlignment, testMode, dst, textType)
+	// Effects are cast by the glyph coverage, so the glyphs are first rendered into a mask
+	if effects {
+		// The mask leaves a line of room for descenders and glyphs overhanging the layout
+		insets := effectInsets(textConfig)
+		bounds := image.Rect(
+			layoutArea.X-insets.Left-layout.LineHeight,
+			layoutArea.Y-insets.Top-layout.LineHeight,
+			layoutArea.X+layoutArea.Width+insets.Right+layout.LineHeight,
+			layoutArea.Y+layoutArea.Height+insets.Bottom+layout.LineHeight,
+		).Intersect(dst.Bounds())
+		mask := image.NewAlpha(bounds)
+		ir.renderTextLines(mask, image.Opaque, nil, layout, origins)
+		drawTextEffects(dst, mask, textConfig)
+	}
+	ir.renderTextLines(dst, src, textConfig, layout, origins)
+
+	if testMode {
+		ir.drawTestBorder(dst, area, textType)
+	}
 
 	return nil
 }
 
-// adjustFontSizeToFit automatically reduces font size until text fits within the specified area.
-// It iteratively shrinks the font while respecting the minimum size constraint.
-func (ir *ImageRenderer) adjustFontSizeToFit(font *truetype.Font, title string, textConfig *TextConfig, area TextArea, maxWidth int, lines []string, textProcessor *TextProcessor) (float64, font.Face, []string) {
-	fontSize := textConfig.Size
-	maxHeight := area.Height
+// adjustFontSizeToFit finds the largest font size between the minimum size and the
+// configured size (the maximum size in fit mode) at which the wrapped text fits within
+// the area and max_lines. It uses a binary search over the font size; if even the
+// minimum size does not fit, the layout at the minimum size is returned.
+func (ir *ImageRenderer) adjustFontSizeToFit(font *truetype.Font, text string, textConfig *TextConfig, area TextArea, textProcessor *TextProcessor) *TextLayout {
+	maxFontSize := fitMaxFontSize(textConfig)
 	minFontSize := textConfig.MinSize
 	if minFontSize <= 0 {
 		minFontSize = DefaultMinFontSize
 	}
 
-	// Add protection against infinite loops
-	maxIterations := 100
-	iterations := 0
-
-	// Validate FontSizeShrinkFactor to prevent infinite loops
-	if FontSizeShrinkFactor <= 0 || FontSizeShrinkFactor >= 1.0 {
-		// Use a safe fallback if the constant is invalid
-		fontSize = minFontSize
-	} else {
-		for fontSize > minFontSize && iterations < maxIterations {
-			lineHeight := int(fontSize * textConfig.LineHeight)
-			totalHeight := len(lines) * lineHeight
-
-			var maxTextWidth int
-			face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-			for _, line := range lines {
-				textWidthPx := measureStringWithSpacing(face, line, textConfig.LetterSpacing)
-				if textWidthPx > maxTextWidth {
-					maxTextWidth = textWidthPx
-				}
-			}
+	fits := func(layout *TextLayout) bool {
+		return layout.fitsIn(area) && layout.withinLines(textConfig.MaxLines)
+	}
 
-			if totalHeight <= maxHeight && maxTextWidth <= area.Width {
-				break
-			}
+	layout := ir.layoutElement(font, text, maxFontSize, textConfig, area.Width, textProcessor)
+	if fits(layout) || maxFontSize <= minFontSize {
+		return layout
+	}
 
-			fontSize = fontSize * FontSizeShrinkFactor
-			face = truetype.NewFace(font, &truetype.Options{Size: fontSize})
-			lines = textProcessor.SplitText(title, face, maxWidth)
-			iterations++
-		}
+	best := ir.layoutElement(font, text, minFontSize, textConfig, area.Width, textProcessor)
+	if !fits(best) {
+		return best
+	}
 
-		// Ensure minimum font size is respected
-		if fontSize < minFontSize {
-			fontSize = minFontSize
+	// Invariant: text fits at low and does not fit at high
+	low, high := minFontSize, maxFontSize
+	for i := 0; high-low > FontSizeSearchPrecision && i < FontSizeSearchMaxIterations; i++ {
+		mid := (low + high) / 2
+		candidate := ir.layoutElement(font, text, mid, textConfig, area.Width, textProcessor)
+		if fits(candidate) {
+			low, best = mid, candidate
+		} else {
+			high = mid
 		}
 	}
 
-	face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-	return fontSize, face, lines
+	return best
 }
 
-// renderTextLines draws multiple lines of text with proper positioning and alignment.
-// It handles both block-level alignment (within the text area) and line-level alignment.
-func (ir *ImageRenderer) renderTextLines(c *freetype.Context, face font.Face, lines []string, textConfig *TextConfig, area TextArea, alignment, lineAlignment string, testMode bool, dst *image.RGBA, textType string) {
-	fontSize := textConfig.Size
-	lineHeight := int(fontSize * textConfig.LineHeight)
-	totalHeight := len(lines) * lineHeight
-
-	var maxTextWidth int
-	for _, line := range lines {
-		textWidthPx := measureStringWithSpacing(face, line, textConfig.LetterSpacing)
-		if textWidthPx > maxTextWidth {
-			maxTextWidth = textWidthPx
-		}
+// fitMaxFontSize returns the largest font size the text of an element may take.
+// Fit mode grows text up to the maximum size, but never below the configured size.
+func fitMaxFontSize(textConfig *TextConfig) float64 {
+	if textConfig.Overflow == OverflowFit && textConfig.MaxSize > textConfig.Size {
+		return textConfig.MaxSize
 	}
+	return textConfig.Size
+}
 
-	blockX, blockY := calculateTextPosition(area, alignment, maxTextWidth, totalHeight)
+// layoutElement lays out the text of an element at the given font size. With markup or
+// highlight rules, the text is split into styled runs that are wrapped with the faces of their styles.
+func (ir *ImageRenderer) layoutElement(font *truetype.Font, text string, fontSize float64, textConfig *TextConfig, maxWidth int, textProcessor *TextProcessor) *TextLayout {
+	runs := ir.textRuns(textConfig, text)
+	if runs == nil {
+		return layoutText(font, text, fontSize, textConfig, maxWidth, textProcessor)
+	}
 
-	for i, line := range lines {
-		textWidthPx := measureStringWithSpacing(face, line, textConfig.LetterSpacing)
+	var code *truetype.Font
+	if textConfig.Markup.Enabled {
+		code = ir.markupCodeFont(&textConfig.Markup, font)
+	}
+	faces := newRunFaces(font, code, ir.highlightFonts(textConfig.Highlights), fontSize, textConfig)
+	return layoutRuns(faces, runs, textConfig, maxWidth, textProcessor)
+}
 
-		var lineX int
-		switch lineAlignment {
-		case "left":
-			lineX = blockX
-		case "right":
-			lineX = blockX + maxTextWidth - textWidthPx
-		default:
-			lineX = blockX + (maxTextWidth-textWidthPx)/2
+// renderTextLines draws the laid out lines starting at their origins. Styled runs are painted
+// with their own colors when the text configuration is given; other glyphs use src.
+func (ir *ImageRenderer) renderTextLines(dst draw.Image, src image.Image, textConfig *TextConfig, layout *TextLayout, origins []image.Point) {
+	for i, origin := range origins {
+		if layout.Runs == nil {
+			drawStringWithSpacing(dst, src, layout.Face, layout.Lines[i], origin.X, origin.Y, layout.LetterSpacing)
+			continue
 		}
 
-		y := blockY + lineHeight + i*lineHeight
-
-		err := drawStringWithSpacing(c, face, line, lineX, y, textConfig.LetterSpacing)
-		if err != nil {
-			DefaultLogger.Warning("Failed to draw text line %d: %v", i, err)
+		runs := layout.Runs[i]
+		for j, extent := range runExtents(runs, fixed.I(origin.X), layout.LetterSpacing) {
+			runSrc := src
+			if textConfig != nil {
+				runSrc = runSource(textConfig, runs[j].textRun, src)
+			}
+			drawStringWithSpacingFixed(dst, runSrc, runs[j].Face, runs[j].Text, extent[0], fixed.I(origin.Y), layout.LetterSpacing)
 		}
 	}
-
-	if testMode {
-		ir.drawTestBorder(dst, area, textType)
-	}
 }
 
 // drawTestBorder draws a colored border around the text area for debugging purposes.
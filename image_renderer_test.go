@@ -369,15 +369,14 @@ func TestImageRenderer_adjustFontSizeToFit(t *testing.T) {
 	}
 
 	title := "Very Long Title That Should Be Shrunk"
-	initialLines := []string{title}
 
 	// Create text processor for testing
 	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
 	textProcessor := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
 
 	// Test font size adjustment
-	adjustedSize, adjustedFace, adjustedLines := renderer.adjustFontSizeToFit(
-		font, title, textConfig, area, area.Width, initialLines, textProcessor)
+	layout := renderer.adjustFontSizeToFit(font, title, textConfig, area, textProcessor)
+	adjustedSize, adjustedFace, adjustedLines := layout.FontSize, layout.Face, layout.Lines
 
 	// Font size should be reduced
 	if adjustedSize >= 100.0 {
@@ -433,14 +432,12 @@ func TestImageRenderer_adjustFontSizeToFit_WithMinSize(t *testing.T) {
 	}
 
 	title := "Test"
-	initialLines := []string{title}
 
 	// Create text processor for testing
 	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
 	textProcessor := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
 
-	adjustedSize, _, _ := renderer.adjustFontSizeToFit(
-		font, title, textConfig, area, area.Width, initialLines, textProcessor)
+	adjustedSize := renderer.adjustFontSizeToFit(font, title, textConfig, area, textProcessor).FontSize
 
 	// Should not go below default minimum of 12.0
 	if adjustedSize < 12.0 {
@@ -448,7 +445,7 @@ func TestImageRenderer_adjustFontSizeToFit_WithMinSize(t *testing.T) {
 	}
 }
 
-func TestImageRenderer_renderSingleText_ColorParsing(t *testing.T) {
+func TestImageRenderer_RenderTextElement_ColorParsing(t *testing.T) {
 	// Load font
 	font, err := truetype.Parse(goregular.TTF)
 	if err != nil {
@@ -480,9 +477,9 @@ func TestImageRenderer_renderSingleText_ColorParsing(t *testing.T) {
 		},
 	}
 
-	err = renderer.renderSingleText(img, font, textConfig, "Test", false, "title")
+	err = renderer.RenderTextElement(img, font, textConfig, "Test", false, "title")
 	if err != nil {
-		t.Errorf("renderSingleText should not return error even with invalid color: %v", err)
+		t.Errorf("RenderTextElement should not return error even with invalid color: %v", err)
 	}
 }
 
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newFilledRGBA(width, height int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+func TestCompositeImageWithBlend_StraightAlphaSource(t *testing.T) {
+	dst := newFilledRGBA(4, 4, color.Black)
+
+	// Semi-transparent white PNG pixels are decoded as straight alpha (NRGBA)
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.NRGBA{R: 255, G: 255, B: 255, A: 128}), image.Point{}, draw.Src)
+
+	compositeImageWithBlend(dst, src, 1, 1, 1.0, BlendSRGB)
+
+	got := dst.RGBAAt(1, 1)
+	// 50% white over black must be mid gray, not the darker value from double multiplication
+	if absDiff(got.R, 128) > 1 || got.A != 255 {
+		t.Errorf("Expected mid gray, got %v", got)
+	}
+	if dst.RGBAAt(0, 0) != (color.RGBA{A: 255}) {
+		t.Error("Pixels outside the source should be untouched")
+	}
+}
+
+func TestCompositeImageWithBlend_UniformOpacity(t *testing.T) {
+	dst := newFilledRGBA(2, 2, color.RGBA{B: 255, A: 255})
+	src := newFilledRGBA(2, 2, color.RGBA{R: 255, A: 255})
+
+	compositeImageWithBlend(dst, src, 0, 0, 0.5, BlendSRGB)
+
+	got := dst.RGBAAt(0, 0)
+	if absDiff(got.R, 128) > 1 || absDiff(got.B, 127) > 1 || got.A != 255 {
+		t.Errorf("Expected even red/blue mix, got %v", got)
+	}
+}
+
+func TestCompositeImageWithBlend_ZeroOpacityAndClipping(t *testing.T) {
+	dst := newFilledRGBA(4, 4, color.White)
+	src := newFilledRGBA(4, 4, color.Black)
+
+	compositeImageWithBlend(dst, src, 0, 0, 0, BlendSRGB)
+	if dst.RGBAAt(0, 0) != (color.RGBA{255, 255, 255, 255}) {
+		t.Error("Zero opacity should leave the destination unchanged")
+	}
+
+	// Partially outside the canvas must not panic and only affect the overlap
+	compositeImageWithBlend(dst, src, 2, -2, 1.0, BlendSRGB)
+	if dst.RGBAAt(3, 0) != (color.RGBA{A: 255}) {
+		t.Errorf("Expected overlap to be black, got %v", dst.RGBAAt(3, 0))
+	}
+	if dst.RGBAAt(0, 3) != (color.RGBA{255, 255, 255, 255}) {
+		t.Error("Area outside the overlap should be unchanged")
+	}
+}
+
+func TestCompositeImageWithBlend_Linear(t *testing.T) {
+	srgb := newFilledRGBA(2, 2, color.Black)
+	linear := newFilledRGBA(2, 2, color.Black)
+	src := newFilledRGBA(2, 2, color.White)
+
+	compositeImageWithBlend(srgb, src, 0, 0, 0.5, BlendSRGB)
+	compositeImageWithBlend(linear, src, 0, 0, 0.5, BlendLinear)
+
+	// 50% coverage in linear light encodes to about 188 in sRGB
+	if got := linear.RGBAAt(0, 0); absDiff(got.R, 188) > 2 || got.A != 255 {
+		t.Errorf("Expected linear-light blend of about 188, got %v", got)
+	}
+	if got := srgb.RGBAAt(0, 0); absDiff(got.R, 128) > 1 {
+		t.Errorf("Expected sRGB blend of about 128, got %v", got)
+	}
+}
+
+func TestCompositeImageWithBlend_LinearKeepsOpaquePixels(t *testing.T) {
+	dst := newFilledRGBA(2, 2, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+	src := newFilledRGBA(2, 2, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+
+	compositeImageWithBlend(dst, src, 0, 0, 1.0, BlendLinear)
+
+	got := dst.RGBAAt(1, 1)
+	if absDiff(got.R, 200) > 1 || absDiff(got.G, 100) > 1 || absDiff(got.B, 50) > 1 {
+		t.Errorf("Opaque source should replace destination, got %v", got)
+	}
+}
+
+func TestResolveBlendSpace(t *testing.T) {
+	for _, blend := range []string{BlendSRGB, BlendLinear} {
+		output := captureOutput(func() {
+			if got := resolveBlendSpace(blend); got != blend {
+				t.Errorf("resolveBlendSpace(%q) = %q", blend, got)
+			}
+		})
+		if output != "" {
+			t.Errorf("Expected no warning for %q, got %q", blend, output)
+		}
+	}
+
+	output := captureOutput(func() {
+		if got := resolveBlendSpace("linaer"); got != BlendSRGB {
+			t.Errorf("Expected sRGB blending for an unknown value, got %q", got)
+		}
+	})
+	if !strings.Contains(output, "Warning") || !strings.Contains(output, "linaer") {
+		t.Errorf("Expected a warning naming the unknown blend, got %q", output)
+	}
+}
+
+func TestCompositeCustomImage_CoverCrop(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Left half red, right half blue
+	src := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(src, image.Rect(0, 0, 100, 100), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+	draw.Draw(src, image.Rect(100, 0, 200, 100), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+
+	file, err := os.Create(filepath.Join(tempDir, "overlay.png"))
+	if err != nil {
+		t.Fatalf("Failed to create overlay: %v", err)
+	}
+	if err := png.Encode(file, src); err != nil {
+		t.Fatalf("Failed to encode overlay: %v", err)
+	}
+	file.Close()
+
+	width, height := 50, 50
+	imagePath := "overlay.png"
+	overlay := MainOverlayConfig{
+		Visible:   true,
+		Image:     &imagePath,
+		Placement: PlacementConfig{X: 10, Y: 10, Width: &width, Height: &height},
+		Fit:       "cover",
+		Opacity:   1.0,
+	}
+
+	dst := newFilledRGBA(100, 100, color.White)
+	if err := compositeCustomImage(dst, tempDir, overlay, false, tempDir); err != nil {
+		t.Fatalf("compositeCustomImage failed: %v", err)
+	}
+
+	// The center crop shows the red/blue boundary in the middle of the target area
+	if got := dst.RGBAAt(15, 35); got.R < 200 || got.B > 50 {
+		t.Errorf("Expected red on the left of the crop, got %v", got)
+	}
+	if got := dst.RGBAAt(55, 35); got.B < 200 || got.R > 50 {
+		t.Errorf("Expected blue on the right of the crop, got %v", got)
+	}
+	if got := dst.RGBAAt(65, 35); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("Expected crop to stay within 50px, got %v", got)
+	}
+}
+
+func BenchmarkCompositeImage(b *testing.B) {
+	dst := newFilledRGBA(DefaultImageWidth, DefaultImageHeight, color.White)
+	src := image.NewNRGBA(image.Rect(0, 0, DefaultImageWidth, DefaultImageHeight))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.NRGBA{R: 255, A: 128}), image.Point{}, draw.Src)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compositeImageWithBlend(dst, src, 0, 0, 0.8, BlendSRGB)
+	}
+}
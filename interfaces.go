@@ -64,6 +64,7 @@ type ImageOverlaySettings interface {
 	GetPlacement() *PlacementConfig
 	GetFit() *string
 	GetOpacity() *float64
+	GetBlend() *string
 }
 
 // FileSystem interface for file system operations (useful for testing).
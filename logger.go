@@ -14,6 +14,9 @@ func NewLogger() *Logger {
 }
 
 // Warning logs a warning message.
+// Problems with decorative parts of the image, such as an invalid color, shape or regular
+// expression or a missing overlay image, are logged as warnings and the part is skipped or
+// falls back to a default, so that they do not prevent the OGP image from being generated.
 func (l *Logger) Warning(format string, args ...interface{}) {
 	fmt.Printf("Warning: "+format+"\n", args...)
 }
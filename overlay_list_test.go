@@ -0,0 +1,127 @@
+package main
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"testing"
+)
+
+// TestOverlayListMergeByID tests that overlays are merged by id across configuration levels.
+func TestOverlayListMergeByID(t *testing.T) {
+	globalSettings := &ConfigSettings{
+		Overlays: []OverlayConfigSettings{
+			{ID: "logo", Image: stringPtr("logo.png"), Placement: &PlacementSettings{X: intPtr(1000), Y: intPtr(20)}, Z: intPtr(10)},
+			{ID: "avatar", Image: stringPtr("default-avatar.png"), Opacity: float64Ptr(0.8)},
+		},
+	}
+	typeSettings := &ConfigSettings{
+		Overlays: []OverlayConfigSettings{
+			{ID: "avatar", Image: stringPtr("blog-avatar.png")},
+			{ID: "badge", Image: stringPtr("badge.png"), Z: intPtr(-1)},
+		},
+	}
+	frontMatter := &OGPFrontMatter{
+		Overlays: []ArticleOverlayConfig{
+			{ID: "avatar", Visible: boolPtr(false)},
+			{ID: "logo", Placement: &PlacementSettings{Y: intPtr(40)}},
+		},
+	}
+
+	merger := NewConfigMerger()
+	config := merger.MergeConfigsWithSettings(getDefaultConfig(), globalSettings, typeSettings, frontMatter)
+
+	if len(config.Overlays) != 3 {
+		t.Fatalf("Expected 3 overlays, got %d", len(config.Overlays))
+	}
+
+	logo := config.Overlays[0]
+	if logo.ID != "logo" || !logo.Visible || *logo.Image != "logo.png" {
+		t.Errorf("Unexpected logo overlay: %+v", logo)
+	}
+	if logo.Placement.X != 1000 || logo.Placement.Y != 40 || logo.Z != 10 {
+		t.Errorf("Expected logo placement (1000, 40) z 10, got (%d, %d) z %d", logo.Placement.X, logo.Placement.Y, logo.Z)
+	}
+
+	avatar := config.Overlays[1]
+	if avatar.ID != "avatar" || avatar.Visible {
+		t.Errorf("Expected avatar overlay to be hidden, got %+v", avatar)
+	}
+	if *avatar.Image != "blog-avatar.png" || avatar.Opacity != 0.8 {
+		t.Errorf("Expected avatar to keep type image and global opacity, got %q %.2f", *avatar.Image, avatar.Opacity)
+	}
+
+	badge := config.Overlays[2]
+	if badge.ID != "badge" || badge.Z != -1 || badge.Fit != DefaultOverlayFit || badge.Opacity != DefaultOverlayOpacity {
+		t.Errorf("Unexpected badge overlay: %+v", badge)
+	}
+}
+
+// TestOverlayListDoesNotModifyBaseConfig tests that front matter overlay overrides do not leak into the base config.
+func TestOverlayListDoesNotModifyBaseConfig(t *testing.T) {
+	base := getDefaultConfig()
+	base.Overlays = []MainOverlayConfig{newOverlayListEntry("logo")}
+	base.Overlays[0].Image = stringPtr("logo.png")
+
+	merger := NewConfigMerger()
+	merged := merger.MergeConfigs(base, &OGPFrontMatter{
+		Overlays: []ArticleOverlayConfig{
+			{ID: "logo", Image: stringPtr("other.png"), Z: intPtr(5)},
+			{Image: stringPtr("extra.png")},
+		},
+	})
+
+	if len(merged.Overlays) != 2 || *merged.Overlays[0].Image != "other.png" || merged.Overlays[0].Z != 5 {
+		t.Errorf("Unexpected merged overlays: %+v", merged.Overlays)
+	}
+	if len(base.Overlays) != 1 || *base.Overlays[0].Image != "logo.png" || base.Overlays[0].Z != 0 {
+		t.Errorf("Base config overlays were modified: %+v", base.Overlays)
+	}
+}
+
+// TestVisibleOverlays tests that hidden overlays and overlays without an image are skipped.
+func TestVisibleOverlays(t *testing.T) {
+	config := getDefaultConfig()
+	config.Overlay.Visible = true
+	config.Overlay.Image = stringPtr("main.png")
+
+	top := newOverlayListEntry("top")
+	top.Image = stringPtr("top.png")
+	top.Z = 5
+	hidden := newOverlayListEntry("hidden")
+	hidden.Image = stringPtr("hidden.png")
+	hidden.Visible = false
+	noImage := newOverlayListEntry("no-image")
+	bottom := newOverlayListEntry("bottom")
+	bottom.Image = stringPtr("bottom.png")
+	bottom.Z = -1
+	config.Overlays = []MainOverlayConfig{top, hidden, noImage, bottom}
+
+	var images []string
+	for _, overlay := range visibleOverlays(config) {
+		images = append(images, *overlay.Image)
+	}
+	expected := []string{"main.png", "top.png", "bottom.png"}
+	if len(images) != len(expected) {
+		t.Fatalf("Expected overlays %v, got %v", expected, images)
+	}
+	for i := range expected {
+		if images[i] != expected[i] {
+			t.Errorf("Expected overlays %v, got %v", expected, images)
+			break
+		}
+	}
+}
+
+// writeSolidPNG writes a 4x4 PNG filled with a single color.
+func writeSolidPNG(t *testing.T, path string, c color.RGBA) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, newFilledRGBA(4, 4, c)); err != nil {
+		t.Fatalf("Failed to encode %s: %v", path, err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"image"
+	"sort"
+)
+
+// SceneLayer is a single drawable element of the generated image,
+// such as the background, an overlay image or a text element.
+type SceneLayer struct {
+	Name string                      // Layer name used in logs and errors
+	Z    int                         // Stacking order (higher values are drawn on top)
+	Draw func(dst *image.RGBA) error // Draws the layer onto the canvas
+}
+
+// Scene is an ordered collection of layers composed onto a single canvas.
+// Layers are drawn in ascending z order; layers with the same z index
+// are drawn in the order they were added.
+type Scene struct {
+	layers []SceneLayer
+}
+
+// NewScene creates an empty Scene.
+func NewScene() *Scene {
+	return &Scene{}
+}
+
+// AddLayer appends a layer to the scene.
+func (s *Scene) AddLayer(name string, z int, draw func(dst *image.RGBA) error) {
+	s.layers = append(s.layers, SceneLayer{Name: name, Z: z, Draw: draw})
+}
+
+// Layers returns the layers in drawing order.
+func (s *Scene) Layers() []SceneLayer {
+	layers := make([]SceneLayer, len(s.layers))
+	copy(layers, s.layers)
+
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].Z < layers[j].Z
+	})
+
+	return layers
+}
+
+// Render draws all layers onto dst in drawing order.
+// Rendering stops at the first layer that returns an error.
+func (s *Scene) Render(dst *image.RGBA) error {
+	for _, layer := range s.Layers() {
+		if err := layer.Draw(dst); err != nil {
+			return NewRenderError(layer.Name+" layer", err)
+		}
+	}
+	return nil
+}
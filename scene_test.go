@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestScene_LayersOrder tests that layers are sorted by z and keep insertion order for equal z.
+func TestScene_LayersOrder(t *testing.T) {
+	scene := NewScene()
+	noop := func(dst *image.RGBA) error { return nil }
+	scene.AddLayer("a", 1, noop)
+	scene.AddLayer("b", 0, noop)
+	scene.AddLayer("c", 1, noop)
+	scene.AddLayer("d", -5, noop)
+
+	var names []string
+	for _, layer := range scene.Layers() {
+		names = append(names, layer.Name)
+	}
+
+	if got := strings.Join(names, ","); got != "d,b,a,c" {
+		t.Errorf("Expected layer order d,b,a,c, got %s", got)
+	}
+}
+
+// TestScene_RenderError tests that rendering stops at the first failing layer.
+func TestScene_RenderError(t *testing.T) {
+	scene := NewScene()
+	drawn := false
+	scene.AddLayer("broken", 0, func(dst *image.RGBA) error { return errors.New("boom") })
+	scene.AddLayer("after", 1, func(dst *image.RGBA) error {
+		drawn = true
+		return nil
+	})
+
+	err := scene.Render(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	if err == nil {
+		t.Fatal("Expected render error")
+	}
+	if !strings.Contains(err.Error(), "broken layer") {
+		t.Errorf("Expected error to name the failing layer, got %v", err)
+	}
+	if drawn {
+		t.Error("Expected rendering to stop after the failing layer")
+	}
+}
+
+// TestArticleProcessor_BuildScene tests the default and reordered layer stacks.
+func TestArticleProcessor_BuildScene(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(config *Config)
+		expected string
+	}{
+		{
+			name:     "default order",
+			setup:    func(config *Config) {},
+			expected: "background,overlay 'logo',title,description",
+		},
+		{
+			name: "overlay above text",
+			setup: func(config *Config) {
+				config.Overlays[0].Z = 10
+			},
+			expected: "background,title,description,overlay 'logo'",
+		},
+		{
+			name: "title below frame overlay",
+			setup: func(config *Config) {
+				config.Title.Z = -1
+				config.Background.Z = -2
+			},
+			expected: "background,title,overlay 'logo',description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getDefaultConfig()
+			config.Description.Visible = true
+			logo := newOverlayListEntry("logo")
+			logo.Image = stringPtr("logo.png")
+			config.Overlays = []MainOverlayConfig{logo}
+			tt.setup(config)
+
+			ap := &ArticleProcessor{imageRenderer: NewImageRenderer()}
+			background := image.NewRGBA(image.Rect(0, 0, 10, 10))
+			scene := ap.buildScene(background, nil, config, &ArticleContent{Title: "Title", Description: "Description"}, "", false)
+
+			var names []string
+			for _, layer := range scene.Layers() {
+				names = append(names, layer.Name)
+			}
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("Expected layers %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestArticleProcessor_OverlayLayersZOrder tests that overlays with a higher z index are drawn on top.
+func TestArticleProcessor_OverlayLayersZOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeSolidPNG(t, filepath.Join(dir, "red.png"), color.RGBA{R: 255, A: 255})
+	writeSolidPNG(t, filepath.Join(dir, "blue.png"), color.RGBA{B: 255, A: 255})
+
+	config := getDefaultConfig()
+	red := newOverlayListEntry("red")
+	red.Image = stringPtr("red.png")
+	red.Placement = PlacementConfig{X: 0, Y: 0}
+	red.Z = 2
+	blue := newOverlayListEntry("blue")
+	blue.Image = stringPtr("blue.png")
+	blue.Placement = PlacementConfig{X: 0, Y: 0}
+	blue.Z = 1
+	config.Overlays = []MainOverlayConfig{red, blue}
+
+	ap := &ArticleProcessor{configDir: dir}
+	scene := NewScene()
+	ap.addOverlayLayers(scene, config, dir)
+
+	dst := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	if err := scene.Render(dst); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if got := dst.RGBAAt(2, 2); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("Expected red overlay on top, got %v", got)
+	}
+}
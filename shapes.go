@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/vector"
+)
+
+// bezierArcFactor is the control point distance for approximating
+// a quarter circle with a cubic Bézier curve.
+const bezierArcFactor = 0.5522847498
+
+// pathWriter adds path segments in image coordinates to a rasterizer
+// whose origin is placed at the given offset.
+type pathWriter struct {
+	z      *vector.Rasterizer
+	offset image.Point
+}
+
+func (p *pathWriter) moveTo(x, y float64) {
+	p.z.MoveTo(float32(x-float64(p.offset.X)), float32(y-float64(p.offset.Y)))
+}
+
+func (p *pathWriter) lineTo(x, y float64) {
+	p.z.LineTo(float32(x-float64(p.offset.X)), float32(y-float64(p.offset.Y)))
+}
+
+func (p *pathWriter) cubeTo(x1, y1, x2, y2, x, y float64) {
+	ox, oy := float64(p.offset.X), float64(p.offset.Y)
+	p.z.CubeTo(float32(x1-ox), float32(y1-oy), float32(x2-ox), float32(y2-oy), float32(x-ox), float32(y-oy))
+}
+
+// roundedRectPath adds a rectangle with rounded corners.
+// The radius is clamped to half of the shorter side, so large values produce a pill shape.
+func (p *pathWriter) roundedRectPath(x0, y0, x1, y1, radius float64) {
+	r := math.Max(0, math.Min(radius, math.Min(x1-x0, y1-y0)/2))
+	k := r * bezierArcFactor
+
+	p.moveTo(x0+r, y0)
+	p.lineTo(x1-r, y0)
+	p.cubeTo(x1-r+k, y0, x1, y0+r-k, x1, y0+r)
+	p.lineTo(x1, y1-r)
+	p.cubeTo(x1, y1-r+k, x1-r+k, y1, x1-r, y1)
+	p.lineTo(x0+r, y1)
+	p.cubeTo(x0+r-k, y1, x0, y1-r+k, x0, y1-r)
+	p.lineTo(x0, y0+r)
+	p.cubeTo(x0, y0+r-k, x0+r-k, y0, x0+r, y0)
+	p.z.ClosePath()
+}
+
+// ellipsePath adds an ellipse centered at (cx, cy).
+func (p *pathWriter) ellipsePath(cx, cy, rx, ry float64) {
+	kx, ky := rx*bezierArcFactor, ry*bezierArcFactor
+
+	p.moveTo(cx+rx, cy)
+	p.cubeTo(cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	p.cubeTo(cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	p.cubeTo(cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	p.cubeTo(cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	p.z.ClosePath()
+}
+
+// polygonPath adds a closed polygon through the points.
+func (p *pathWriter) polygonPath(points []ShapePoint) {
+	p.moveTo(points[0].X, points[0].Y)
+	for _, point := range points[1:] {
+		p.lineTo(point.X, point.Y)
+	}
+	p.z.ClosePath()
+}
+
+// unionPath rasterizes the path built within bounds and merges its coverage into mask.
+// The path is rasterized separately so that overlapping paths never cancel each other out.
+func unionPath(mask *image.Alpha, bounds image.Rectangle, build func(p *pathWriter)) {
+	if bounds.Empty() || !bounds.Overlaps(mask.Bounds()) {
+		return
+	}
+
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	build(&pathWriter{z: z, offset: bounds.Min})
+
+	coverage := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	z.Draw(coverage, coverage.Bounds(), image.Opaque, image.Point{})
+	draw.Draw(mask, bounds, coverage, image.Point{}, draw.Over)
+}
+
+// subtractMask removes the coverage of cut from mask.
+func subtractMask(mask, cut *image.Alpha) {
+	bounds := mask.Bounds().Intersect(cut.Bounds())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			a := uint32(mask.AlphaAt(x, y).A)
+			c := uint32(cut.AlphaAt(x, y).A)
+			mask.SetAlpha(x, y, color.Alpha{A: uint8(a * (255 - c) / 255)})
+		}
+	}
+}
+
+// boundsOf returns the pixel rectangle covering the float rectangle expanded by margin.
+func boundsOf(x0, y0, x1, y1, margin float64) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(x0-margin)), int(math.Floor(y0-margin)),
+		int(math.Ceil(x1+margin)), int(math.Ceil(y1+margin)),
+	)
+}
+
+// fillRoundedRect draws an anti-aliased rectangle with rounded corners.
+// The radius is clamped to half of the shorter side, so large values produce a pill shape.
+func fillRoundedRect(dst draw.Image, rect image.Rectangle, radius float64, c color.Color) {
+	if rect.Empty() {
+		return
+	}
+
+	mask := image.NewAlpha(rect)
+	unionPath(mask, rect, func(p *pathWriter) {
+		p.roundedRectPath(float64(rect.Min.X), float64(rect.Min.Y), float64(rect.Max.X), float64(rect.Max.Y), radius)
+	})
+	draw.DrawMask(dst, rect, image.NewUniform(c), image.Point{}, mask, rect.Min, draw.Over)
+}
+
+// drawShape draws a shape with its fill, stroke and opacity onto dst.
+// The fill is drawn first and the stroke is centered on the shape outline.
+func drawShape(dst *image.RGBA, shape *ShapeConfig) error {
+	fill, stroke, err := shapeColors(shape)
+	if err != nil {
+		return err
+	}
+
+	bounds := dst.Bounds()
+	fillMask := image.NewAlpha(bounds)
+	strokeMask := image.NewAlpha(bounds)
+	halfStroke := shape.StrokeWidth / 2
+
+	x0, y0 := float64(shape.X), float64(shape.Y)
+	x1, y1 := x0+float64(shape.Width), y0+float64(shape.Height)
+
+	switch shape.Type {
+	case ShapeRect, ShapeRoundedRect, "":
+		radius := 0.0
+		if shape.Type == ShapeRoundedRect {
+			radius = shape.Radius
+		}
+		if fill != nil {
+			unionPath(fillMask, boundsOf(x0, y0, x1, y1, 0), func(p *pathWriter) {
+				p.roundedRectPath(x0, y0, x1, y1, radius)
+			})
+		}
+		if stroke != nil {
+			outerRadius := radius
+			if radius > 0 {
+				outerRadius += halfStroke
+			}
+			unionPath(strokeMask, boundsOf(x0, y0, x1, y1, halfStroke), func(p *pathWriter) {
+				p.roundedRectPath(x0-halfStroke, y0-halfStroke, x1+halfStroke, y1+halfStroke, outerRadius)
+			})
+			if x1-x0 > shape.StrokeWidth && y1-y0 > shape.StrokeWidth {
+				inner := image.NewAlpha(bounds)
+				unionPath(inner, boundsOf(x0, y0, x1, y1, 0), func(p *pathWriter) {
+					p.roundedRectPath(x0+halfStroke, y0+halfStroke, x1-halfStroke, y1-halfStroke, radius-halfStroke)
+				})
+				subtractMask(strokeMask, inner)
+			}
+		}
+
+	case ShapeCircle, ShapeEllipse:
+		cx, cy := (x0+x1)/2, (y0+y1)/2
+		rx, ry := (x1-x0)/2, (y1-y0)/2
+		if shape.Type == ShapeCircle {
+			rx = math.Min(rx, ry)
+			ry = rx
+		}
+		if fill != nil {
+			unionPath(fillMask, boundsOf(cx-rx, cy-ry, cx+rx, cy+ry, 0), func(p *pathWriter) {
+				p.ellipsePath(cx, cy, rx, ry)
+			})
+		}
+		if stroke != nil {
+			unionPath(strokeMask, boundsOf(cx-rx, cy-ry, cx+rx, cy+ry, halfStroke), func(p *pathWriter) {
+				p.ellipsePath(cx, cy, rx+halfStroke, ry+halfStroke)
+			})
+			if rx > halfStroke && ry > halfStroke {
+				inner := image.NewAlpha(bounds)
+				unionPath(inner, boundsOf(cx-rx, cy-ry, cx+rx, cy+ry, 0), func(p *pathWriter) {
+					p.ellipsePath(cx, cy, rx-halfStroke, ry-halfStroke)
+				})
+				subtractMask(strokeMask, inner)
+			}
+		}
+
+	case ShapeLine:
+		if len(shape.Points) < 2 {
+			return NewValidationError(fmt.Sprintf("line shape requires at least 2 points, got %d", len(shape.Points)))
+		}
+		// Lines have no interior; the fill color is used when no stroke color is set
+		if stroke == nil {
+			stroke = fill
+		}
+		if stroke != nil {
+			strokePolyline(strokeMask, shape.Points, shape.StrokeWidth, false)
+		}
+
+	case ShapePolygon:
+		if len(shape.Points) < 3 {
+			return NewValidationError(fmt.Sprintf("polygon shape requires at least 3 points, got %d", len(shape.Points)))
+		}
+		if fill != nil {
+			minX, minY, maxX, maxY := pointsBounds(shape.Points)
+			unionPath(fillMask, boundsOf(minX, minY, maxX, maxY, 0), func(p *pathWriter) {
+				p.polygonPath(shape.Points)
+			})
+		}
+		if stroke != nil {
+			strokePolyline(strokeMask, shape.Points, shape.StrokeWidth, true)
+		}
+
+	default:
+		return NewValidationError(fmt.Sprintf("unknown shape type: %s", shape.Type))
+	}
+
+	if fill != nil {
+		draw.DrawMask(dst, bounds, image.NewUniform(fill), image.Point{}, fillMask, bounds.Min, draw.Over)
+	}
+	if stroke != nil {
+		draw.DrawMask(dst, bounds, image.NewUniform(stroke), image.Point{}, strokeMask, bounds.Min, draw.Over)
+	}
+
+	return nil
+}
+
+// strokePolyline adds a stroke of the given width along the points to mask.
+// Segments are joined with round joins; open polylines end with butt caps.
+func strokePolyline(mask *image.Alpha, points []ShapePoint, width float64, closed bool) {
+	if width <= 0 {
+		return
+	}
+	half := width / 2
+
+	segments := len(points) - 1
+	if closed {
+		segments = len(points)
+	}
+
+	for i := 0; i < segments; i++ {
+		a, b := points[i], points[(i+1)%len(points)]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		nx, ny := -dy/length*half, dx/length*half
+
+		quad := []ShapePoint{
+			{X: a.X + nx, Y: a.Y + ny},
+			{X: b.X + nx, Y: b.Y + ny},
+			{X: b.X - nx, Y: b.Y - ny},
+			{X: a.X - nx, Y: a.Y - ny},
+		}
+		minX, minY, maxX, maxY := pointsBounds(quad)
+		unionPath(mask, boundsOf(minX, minY, maxX, maxY, 0), func(p *pathWriter) {
+			p.polygonPath(quad)
+		})
+	}
+
+	for i, point := range points {
+		if !closed && (i == 0 || i == len(points)-1) {
+			continue
+		}
+		unionPath(mask, boundsOf(point.X, point.Y, point.X, point.Y, half), func(p *pathWriter) {
+			p.ellipsePath(point.X, point.Y, half, half)
+		})
+	}
+}
+
+// pointsBounds returns the bounding box of the points.
+func pointsBounds(points []ShapePoint) (minX, minY, maxX, maxY float64) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, point := range points {
+		minX, maxX = math.Min(minX, point.X), math.Max(maxX, point.X)
+		minY, maxY = math.Min(minY, point.Y), math.Max(maxY, point.Y)
+	}
+	return minX, minY, maxX, maxY
+}
+
+// shapeColors parses the fill and stroke colors of a shape and applies its opacity.
+// A nil color means the fill or stroke is not drawn.
+func shapeColors(shape *ShapeConfig) (fill, stroke color.Color, err error) {
+	opacity := clampUnit(shape.Opacity)
+
+	if shape.Fill != "" {
+		fill, err = shapeColor(shape.Fill, opacity)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid fill color: %w", err)
+		}
+	}
+	if shape.Stroke != "" && shape.StrokeWidth > 0 {
+		stroke, err = shapeColor(shape.Stroke, opacity)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid stroke color: %w", err)
+		}
+	}
+	return fill, stroke, nil
+}
+
+// shapeColor parses a hex color and scales its alpha by the opacity.
+func shapeColor(hex string, opacity float64) (color.Color, error) {
+	c, err := parseHexColor(hex)
+	if err != nil {
+		return nil, err
+	}
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: uint8(math.Round(float64(c.A) * opacity))}, nil
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFillRoundedRect(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	red := color.RGBA{R: 255, A: 255}
+
+	fillRoundedRect(dst, image.Rect(0, 0, 40, 20), 100, red)
+
+	if got := dst.RGBAAt(20, 10); got != red {
+		t.Errorf("Expected center to be filled, got %v", got)
+	}
+	if got := dst.RGBAAt(0, 0); got.A != 0 {
+		t.Errorf("Expected pill corner to be transparent, got %v", got)
+	}
+	if got := dst.RGBAAt(1, 10); got.A == 0 {
+		t.Errorf("Expected left edge center to be covered, got %v", got)
+	}
+}
+
+func TestFillRoundedRect_Clipped(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	clip := dst.SubImage(image.Rect(0, 0, 5, 5)).(*image.RGBA)
+
+	// Must not panic when the shape extends beyond the destination
+	fillRoundedRect(clip, image.Rect(-5, -5, 20, 20), 0, color.RGBA{G: 255, A: 255})
+
+	if got := dst.RGBAAt(2, 2); got.G != 255 {
+		t.Errorf("Expected pixel inside clip to be filled, got %v", got)
+	}
+	if got := dst.RGBAAt(7, 7); got.A != 0 {
+		t.Errorf("Expected pixel outside clip to be untouched, got %v", got)
+	}
+}
+
+func TestDrawShape(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	transparent := color.RGBA{}
+
+	tests := []struct {
+		name   string
+		shape  func() ShapeConfig
+		inside []image.Point
+		empty  []image.Point
+	}{
+		{
+			name: "rect fill",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("bar")
+				s.X, s.Y, s.Width, s.Height = 10, 10, 20, 5
+				s.Fill = "#FF0000"
+				return s
+			},
+			inside: []image.Point{{10, 10}, {29, 14}},
+			empty:  []image.Point{{9, 10}, {30, 10}, {10, 15}},
+		},
+		{
+			name: "rect stroke without fill",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("frame")
+				s.X, s.Y, s.Width, s.Height = 10, 10, 30, 30
+				s.Fill = ""
+				s.Stroke = "#FF0000"
+				s.StrokeWidth = 4
+				return s
+			},
+			inside: []image.Point{{8, 20}, {11, 20}, {20, 38}},
+			empty:  []image.Point{{25, 25}, {5, 20}},
+		},
+		{
+			name: "circle uses shorter side",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("dot")
+				s.Type = ShapeCircle
+				s.X, s.Y, s.Width, s.Height = 0, 0, 40, 20
+				s.Fill = "#FF0000"
+				return s
+			},
+			inside: []image.Point{{20, 10}, {12, 10}},
+			empty:  []image.Point{{2, 10}, {38, 10}, {11, 1}},
+		},
+		{
+			name: "ellipse fills box",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("oval")
+				s.Type = ShapeEllipse
+				s.X, s.Y, s.Width, s.Height = 0, 0, 40, 20
+				s.Fill = "#FF0000"
+				return s
+			},
+			inside: []image.Point{{2, 10}, {37, 10}},
+			empty:  []image.Point{{1, 1}, {38, 18}},
+		},
+		{
+			name: "line uses fill color without stroke",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("divider")
+				s.Type = ShapeLine
+				s.Fill = "#FF0000"
+				s.StrokeWidth = 4
+				s.Points = []ShapePoint{{X: 5, Y: 20}, {X: 45, Y: 20}}
+				return s
+			},
+			inside: []image.Point{{10, 19}, {40, 20}},
+			empty:  []image.Point{{10, 24}, {2, 20}, {47, 20}},
+		},
+		{
+			name: "polygon fill",
+			shape: func() ShapeConfig {
+				s := newShapeEntry("triangle")
+				s.Type = ShapePolygon
+				s.Fill = "#FF0000"
+				s.Points = []ShapePoint{{X: 0, Y: 0}, {X: 40, Y: 0}, {X: 0, Y: 40}}
+				return s
+			},
+			inside: []image.Point{{5, 5}, {15, 15}},
+			empty:  []image.Point{{30, 30}, {45, 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := image.NewRGBA(image.Rect(0, 0, 50, 50))
+			shape := tt.shape()
+			if err := drawShape(dst, &shape); err != nil {
+				t.Fatalf("drawShape failed: %v", err)
+			}
+			for _, p := range tt.inside {
+				if got := dst.RGBAAt(p.X, p.Y); got != red {
+					t.Errorf("Expected %v to be filled, got %v", p, got)
+				}
+			}
+			for _, p := range tt.empty {
+				if got := dst.RGBAAt(p.X, p.Y); got != transparent {
+					t.Errorf("Expected %v to be empty, got %v", p, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDrawShape_Opacity(t *testing.T) {
+	dst := newFilledRGBA(10, 10, color.RGBA{A: 255})
+	shape := newShapeEntry("overlay")
+	shape.Width, shape.Height = 10, 10
+	shape.Fill = "#FFFFFF"
+	shape.Opacity = 0.5
+
+	if err := drawShape(dst, &shape); err != nil {
+		t.Fatalf("drawShape failed: %v", err)
+	}
+
+	if got := dst.RGBAAt(5, 5); absDiff(got.R, 128) > 1 {
+		t.Errorf("Expected half-transparent white over black, got %v", got)
+	}
+}
+
+func TestDrawShape_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		shape ShapeConfig
+	}{
+		{"unknown type", ShapeConfig{Type: "star", Fill: "#000000"}},
+		{"line without points", ShapeConfig{Type: ShapeLine, Fill: "#000000", StrokeWidth: 1}},
+		{"polygon with two points", ShapeConfig{Type: ShapePolygon, Fill: "#000000", Points: []ShapePoint{{}, {X: 1}}}},
+		{"invalid fill", ShapeConfig{Type: ShapeRect, Fill: "red", Width: 1, Height: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := drawShape(image.NewRGBA(image.Rect(0, 0, 5, 5)), &tt.shape); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+func TestShapesMergeByID(t *testing.T) {
+	typeSettings := &ConfigSettings{
+		Shapes: []ShapeSettings{
+			{ID: "accent", X: intPtr(0), Y: intPtr(0), Width: intPtr(1200), Height: intPtr(12), Fill: stringPtr("#3366FF")},
+			{ID: "divider", Type: stringPtr(ShapeLine), Points: []ShapePoint{{X: 100, Y: 320}, {X: 1100, Y: 320}}},
+		},
+	}
+	frontMatter := &OGPFrontMatter{
+		Shapes: []ShapeSettings{
+			{ID: "accent", Fill: stringPtr("#FF3366")},
+			{ID: "divider", Visible: boolPtr(false)},
+			{ID: "badge", Type: stringPtr(ShapeCircle), Z: intPtr(5)},
+		},
+	}
+
+	merger := NewConfigMerger()
+	config := merger.MergeConfigsWithSettings(getDefaultConfig(), nil, typeSettings, frontMatter)
+
+	if len(config.Shapes) != 3 {
+		t.Fatalf("Expected 3 shapes, got %d", len(config.Shapes))
+	}
+	accent := config.Shapes[0]
+	if accent.Fill != "#FF3366" || accent.Width != 1200 || accent.Type != ShapeRect || !accent.Visible {
+		t.Errorf("Unexpected accent shape: %+v", accent)
+	}
+	if config.Shapes[1].Visible || len(config.Shapes[1].Points) != 2 {
+		t.Errorf("Expected hidden divider with inherited points, got %+v", config.Shapes[1])
+	}
+	if config.Shapes[2].Type != ShapeCircle || config.Shapes[2].Z != 5 || config.Shapes[2].Fill != DefaultShapeFill {
+		t.Errorf("Unexpected badge shape: %+v", config.Shapes[2])
+	}
+
+	// The type settings must not share the points slice with the merged config
+	config.Shapes[1].Points[0].X = 0
+	if typeSettings.Shapes[1].Points[0].X != 100 {
+		t.Error("Merged config shares points with the settings")
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+)
+
+// tagChip is a single positioned tag chip.
+type tagChip struct {
+	Label      string
+	Rect       image.Rectangle
+	Color      color.Color
+	Background color.Color
+}
+
+// RenderTagChips draws the tags as rounded chips flowing into rows inside the configured area.
+// Chips that do not fit are omitted; a "+N" chip counts them when ShowMore is enabled.
+func (ir *ImageRenderer) RenderTagChips(dst *image.RGBA, f *truetype.Font, tagsConfig *TagsConfig, tags []string, testMode bool) {
+	if len(tags) == 0 {
+		return
+	}
+
+	bounds := dst.Bounds()
+	area := tagsConfig.Area.SetDefaults(bounds.Dx(), bounds.Dy())
+
+	style := FontStyle{Weight: tagsConfig.Weight}
+	warnUnavailableWeight(style, "tags")
+	face := newCachedFace(newTextFace(f, tagsConfig.Size, style))
+	metrics := face.Metrics()
+	ascent := metrics.Ascent.Ceil()
+
+	// Chips are clipped to the area so that an oversized chip does not spill over other elements
+	clip := dst.SubImage(image.Rect(area.X, area.Y, area.X+area.Width, area.Y+area.Height)).(*image.RGBA)
+	for _, chip := range layoutTagChips(face, tagsConfig, tags, area) {
+		fillRoundedRect(clip, chip.Rect, tagsConfig.Radius, chip.Background)
+		x := chip.Rect.Min.X + tagsConfig.PaddingX
+		y := chip.Rect.Min.Y + tagsConfig.PaddingY + ascent
+		drawStringWithSpacing(clip, image.NewUniform(chip.Color), face, chip.Label, x, y, 0)
+	}
+
+	if testMode {
+		ir.drawTestBorder(dst, area, "tags")
+	}
+}
+
+// layoutTagChips positions the chips for the given tags inside the area.
+func layoutTagChips(face font.Face, tagsConfig *TagsConfig, tags []string, area TextArea) []tagChip {
+	metrics := face.Metrics()
+	chipHeight := (metrics.Ascent + metrics.Descent).Ceil() + tagsConfig.PaddingY*2
+
+	chipWidth := func(label string) int {
+		return measureStringWithSpacing(face, label, 0) + tagsConfig.PaddingX*2
+	}
+
+	limit := len(tags)
+	if tagsConfig.Max > 0 && tagsConfig.Max < limit {
+		limit = tagsConfig.Max
+	}
+
+	labels := tags[:limit]
+	widths := make([]int, len(labels))
+	for i, label := range labels {
+		widths[i] = chipWidth(label)
+	}
+	rects := flowChips(widths, chipHeight, tagsConfig.Gap, area, tagsConfig.LineAlignment)
+	moreIndex := -1
+
+	// Replace trailing chips with a "+N" chip until the indicator fits as well
+	if len(rects) < len(tags) && tagsConfig.ShowMore {
+		for shown := len(rects); shown >= 0; shown-- {
+			more := fmt.Sprintf(TagMoreFormat, len(tags)-shown)
+			candidate := append(append([]int{}, widths[:shown]...), chipWidth(more))
+			candidateRects := flowChips(candidate, chipHeight, tagsConfig.Gap, area, tagsConfig.LineAlignment)
+			if len(candidateRects) == len(candidate) {
+				labels = append(append([]string{}, tags[:shown]...), more)
+				rects = candidateRects
+				moreIndex = shown
+				break
+			}
+			if shown == 0 {
+				rects = nil
+			}
+		}
+	}
+
+	chips := make([]tagChip, len(rects))
+	for i, rect := range rects {
+		tag := labels[i]
+		if i == moreIndex {
+			tag = ""
+		}
+		textColor, background := tagChipColors(tagsConfig, tag)
+		chips[i] = tagChip{
+			Label:      labels[i],
+			Rect:       rect,
+			Color:      textColor,
+			Background: background,
+		}
+	}
+	return chips
+}
+
+// flowChips places chips of the given widths into rows within the area.
+// Chips are placed in order and placement stops at the first chip that does not fit.
+// A chip wider than the area is placed on its own row and clipped when drawn.
+func flowChips(widths []int, height, gap int, area TextArea, alignment string) []image.Rectangle {
+	var rects []image.Rectangle
+	rowStart, x, y := 0, 0, 0
+
+	for _, width := range widths {
+		if x > 0 && x+width > area.Width {
+			alignChipRow(rects[rowStart:], x-gap, area.Width, alignment)
+			rowStart, x, y = len(rects), 0, y+height+gap
+		}
+		if y+height > area.Height {
+			break
+		}
+		rects = append(rects, image.Rect(area.X+x, area.Y+y, area.X+x+width, area.Y+y+height))
+		x += width + gap
+	}
+
+	if len(rects) > rowStart {
+		alignChipRow(rects[rowStart:], x-gap, area.Width, alignment)
+	}
+	return rects
+}
+
+// alignChipRow shifts a row of chips horizontally according to the alignment.
+func alignChipRow(row []image.Rectangle, rowWidth, areaWidth int, alignment string) {
+	offset := 0
+	switch alignment {
+	case "center":
+		offset = (areaWidth - rowWidth) / 2
+	case "right":
+		offset = areaWidth - rowWidth
+	}
+	if offset <= 0 {
+		return
+	}
+
+	for i := range row {
+		row[i] = row[i].Add(image.Point{X: offset})
+	}
+}
+
+// sortedTagColorNames returns the tags with color mappings in lexical order.
+func sortedTagColorNames(colors map[string]TagColorConfig) []string {
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tagChipColors returns the text and background colors for a tag.
+// Per-tag mappings take precedence over the default chip colors.
+func tagChipColors(tagsConfig *TagsConfig, tag string) (color.Color, color.Color) {
+	textHex, backgroundHex := tagsConfig.Color, tagsConfig.Background
+	if mapping, exists := tagsConfig.Colors[tag]; exists && tag != "" {
+		if mapping.Color != "" {
+			textHex = mapping.Color
+		}
+		if mapping.Background != "" {
+			backgroundHex = mapping.Background
+		}
+	}
+
+	textColor, err := parseHexColor(textHex)
+	if err != nil {
+		textColor = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+		DefaultLogger.Warning("Failed to parse tag color '%s', using white: %v", textHex, err)
+	}
+	background, err := parseHexColor(backgroundHex)
+	if err != nil {
+		background = color.RGBA{R: 85, G: 85, B: 85, A: 255}
+		DefaultLogger.Warning("Failed to parse tag background '%s', using gray: %v", backgroundHex, err)
+	}
+	return textColor, background
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// newTestTagsConfig returns the default tag chips configuration for tests.
+func newTestTagsConfig() *TagsConfig {
+	config := getDefaultConfig()
+	config.Tags.Visible = true
+	return &config.Tags
+}
+
+func TestFlowChips(t *testing.T) {
+	area := TextArea{X: 10, Y: 20, Width: 100, Height: 50}
+
+	tests := []struct {
+		name      string
+		widths    []int
+		alignment string
+		expected  []image.Rectangle
+	}{
+		{
+			name:      "single row left aligned",
+			widths:    []int{30, 30},
+			alignment: "left",
+			expected:  []image.Rectangle{image.Rect(10, 20, 40, 40), image.Rect(50, 20, 80, 40)},
+		},
+		{
+			name:      "wraps into second row",
+			widths:    []int{40, 40, 40},
+			alignment: "left",
+			expected:  []image.Rectangle{image.Rect(10, 20, 50, 40), image.Rect(60, 20, 100, 40), image.Rect(10, 50, 50, 70)},
+		},
+		{
+			name:      "stops when rows exceed the area height",
+			widths:    []int{60, 60, 60},
+			alignment: "left",
+			expected:  []image.Rectangle{image.Rect(10, 20, 70, 40), image.Rect(10, 50, 70, 70)},
+		},
+		{
+			name:      "right aligned row",
+			widths:    []int{30},
+			alignment: "right",
+			expected:  []image.Rectangle{image.Rect(80, 20, 110, 40)},
+		},
+		{
+			name:      "center aligned row",
+			widths:    []int{20, 20},
+			alignment: "center",
+			expected:  []image.Rectangle{image.Rect(35, 20, 55, 40), image.Rect(65, 20, 85, 40)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rects := flowChips(tt.widths, 20, 10, area, tt.alignment)
+			if len(rects) != len(tt.expected) {
+				t.Fatalf("Expected %d chips, got %d: %v", len(tt.expected), len(rects), rects)
+			}
+			for i := range rects {
+				if rects[i] != tt.expected[i] {
+					t.Errorf("Chip %d: expected %v, got %v", i, tt.expected[i], rects[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLayoutTagChips_MaxAndMore(t *testing.T) {
+	face := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 24})
+	tagsConfig := newTestTagsConfig()
+	tagsConfig.Max = 2
+	tags := []string{"go", "hugo", "ogp", "design"}
+	area := TextArea{X: 0, Y: 0, Width: 1000, Height: 50}
+
+	chips := layoutTagChips(face, tagsConfig, tags, area)
+
+	labels := chipLabels(chips)
+	expected := []string{"go", "hugo", "+2"}
+	if !equalStrings(labels, expected) {
+		t.Errorf("Expected chips %v, got %v", expected, labels)
+	}
+
+	tagsConfig.ShowMore = false
+	labels = chipLabels(layoutTagChips(face, tagsConfig, tags, area))
+	if !equalStrings(labels, []string{"go", "hugo"}) {
+		t.Errorf("Expected chips without indicator, got %v", labels)
+	}
+}
+
+func TestLayoutTagChips_MoreReplacesChipsThatDoNotFit(t *testing.T) {
+	face := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 24})
+	tagsConfig := newTestTagsConfig()
+	tags := []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+
+	// Measure the first two chips so that exactly two fit on one row
+	twoChips := layoutTagChips(face, tagsConfig, tags[:2], TextArea{Width: 1000, Height: 50})
+	area := TextArea{Width: twoChips[1].Rect.Max.X, Height: 50}
+
+	labels := chipLabels(layoutTagChips(face, tagsConfig, tags, area))
+	if len(labels) != 2 || labels[0] != "alpha" || labels[1] != "+4" {
+		t.Errorf("Expected [alpha +4], got %v", labels)
+	}
+}
+
+func TestLayoutTagChips_Colors(t *testing.T) {
+	face := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 24})
+	tagsConfig := newTestTagsConfig()
+	tagsConfig.Colors = map[string]TagColorConfig{
+		"go":   {Background: "#00ADD8"},
+		"hugo": {Color: "#000000", Background: "#FF4088"},
+	}
+	tagsConfig.Max = 2
+
+	chips := layoutTagChips(face, tagsConfig, []string{"go", "hugo", "go"}, TextArea{Width: 1000, Height: 50})
+	if len(chips) != 3 {
+		t.Fatalf("Expected 3 chips, got %d", len(chips))
+	}
+
+	expectColor(t, chips[0].Background, color.RGBA{R: 0x00, G: 0xAD, B: 0xD8, A: 255})
+	expectColor(t, chips[0].Color, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	expectColor(t, chips[1].Color, color.RGBA{A: 255})
+	expectColor(t, chips[1].Background, color.RGBA{R: 0xFF, G: 0x40, B: 0x88, A: 255})
+	expectColor(t, chips[2].Background, color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 255})
+}
+
+func TestApplyTagsSettings(t *testing.T) {
+	globalSettings := &ConfigSettings{
+		Tags: &TagsSettings{
+			Visible: boolPtr(true),
+			Max:     intPtr(3),
+			Colors: map[string]*TagColorSettings{
+				"go": {Color: stringPtr("#000000"), Background: stringPtr("#00ADD8")},
+			},
+		},
+	}
+	frontMatter := &OGPFrontMatter{
+		Tags: &TagsSettings{
+			Background: stringPtr("#222222"),
+			Colors: map[string]*TagColorSettings{
+				"go":   {Background: stringPtr("#FFFFFF")},
+				"hugo": {Background: stringPtr("#FF4088")},
+			},
+		},
+	}
+
+	merger := NewConfigMerger()
+	base := getDefaultConfig()
+	config := merger.MergeConfigsWithSettings(base, globalSettings, nil, frontMatter)
+
+	if !config.Tags.Visible || config.Tags.Max != 3 || config.Tags.Background != "#222222" {
+		t.Errorf("Unexpected tags config: %+v", config.Tags)
+	}
+	if config.Tags.Colors["go"] != (TagColorConfig{Color: "#000000", Background: "#FFFFFF"}) {
+		t.Errorf("Expected go colors to be merged, got %+v", config.Tags.Colors["go"])
+	}
+	if config.Tags.Colors["hugo"].Background != "#FF4088" {
+		t.Errorf("Expected hugo background from front matter, got %+v", config.Tags.Colors["hugo"])
+	}
+	if base.Tags.Colors != nil {
+		t.Errorf("Default config was modified: %+v", base.Tags.Colors)
+	}
+}
+
+func TestImageRenderer_RenderTagChips(t *testing.T) {
+	f := parseTestFont(t)
+	tagsConfig := newTestTagsConfig()
+	tagsConfig.Area = TextArea{X: 10, Y: 10, Width: 300, Height: 60}
+	tagsConfig.Background = "#FF0000"
+
+	dst := newFilledRGBA(320, 80, color.RGBA{A: 255})
+	NewImageRenderer().RenderTagChips(dst, f, tagsConfig, []string{"golang"}, false)
+
+	// The chip starts at the area origin with rounded corners; its left padding is solid background
+	if got := dst.RGBAAt(10+tagsConfig.PaddingX/2, 10+tagsConfig.PaddingY+10); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("Expected chip background inside the chip, got %v", got)
+	}
+	if got := dst.RGBAAt(10, 10); got.R > 128 {
+		t.Errorf("Expected rounded corner to stay mostly unpainted, got %v", got)
+	}
+	if got := dst.RGBAAt(5, 5); got != (color.RGBA{A: 255}) {
+		t.Errorf("Expected pixels outside the area to be untouched, got %v", got)
+	}
+}
+
+func chipLabels(chips []tagChip) []string {
+	labels := make([]string, len(chips))
+	for i, chip := range chips {
+		labels[i] = chip.Label
+	}
+	return labels
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func expectColor(t *testing.T, got color.Color, expected color.RGBA) {
+	t.Helper()
+	if color.RGBAModel.Convert(got).(color.RGBA) != expected {
+		t.Errorf("Expected color %v, got %v", expected, got)
+	}
+}
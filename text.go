@@ -2,16 +2,21 @@ package main
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
 )
 
-// TextProcessor handles Japanese text processing with line breaking rules (禁則処理).
+// TextProcessor breaks text into lines at the line break opportunities of the Unicode line
+// breaking algorithm, tailored by Japanese line breaking rules (禁則処理).
 // It maintains maps of characters that have special line breaking constraints.
 type TextProcessor struct {
 	startProhibited map[rune]bool // Characters that cannot start a line (行頭禁則文字)
 	endProhibited   map[rune]bool // Characters that cannot end a line (行末禁則文字)
 	letterSpacing   int           // Letter spacing in pixels
+	wrap            string        // Line wrapping strategy ("greedy" or "balanced")
 }
 
 // NewTextProcessor creates a new TextProcessor with the given prohibited character maps.
@@ -48,166 +53,126 @@ func (t *TextProcessor) SplitText(text string, face font.Face, maxWidth int) []s
 	return t.splitTextSingle(text, face, maxWidth)
 }
 
-// splitTextSingle processes a single line of text, applying automatic line breaking
-// with Japanese prohibitions and English word boundary awareness.
+// splitTextSingle processes a single line of text, breaking it automatically at the
+// Unicode line break opportunities tailored by the kinsoku rules.
 func (t *TextProcessor) splitTextSingle(text string, face font.Face, maxWidth int) []string {
 	if text == "" {
 		return []string{}
 	}
 
-	// Convert to runes for proper Unicode handling
 	runes := []rune(text)
-	if len(runes) == 0 {
-		return []string{text}
-	}
+	return t.splitRunes(runes, &runeMeasurer{runes: runes, face: face, letterSpacing: t.letterSpacing}, maxWidth)
+}
 
-	var lines []string
-	var currentLine []rune
-
-	i := 0
-	for i < len(runes) {
-		r := runes[i]
-		testLine := append(currentLine, r)
-
-		if t.fitsInWidth(testLine, face, maxWidth) {
-			currentLine = testLine
-			i++
-		} else {
-			newLines, newCurrentLine, newIndex := t.handleLineBreak(lines, currentLine, runes, i, r)
-			lines = newLines
-			currentLine = newCurrentLine
-			i = newIndex
+// SplitRuns breaks styled runs into lines like SplitText. Each rune is measured with the face
+// of its run, so lines break within and across runs. The runs of each line are returned in order.
+func (t *TextProcessor) SplitRuns(runs []textRun, faceOf func(textRun) font.Face, maxWidth int) [][]textRun {
+	var runes []rune
+	var formats []textRun
+	for _, run := range runs {
+		format := textRun{Style: run.Style, Rule: run.Rule}
+		for _, r := range run.Text {
+			runes = append(runes, r)
+			formats = append(formats, format)
 		}
 	}
 
-	// Add any remaining characters as the last line
-	if len(currentLine) > 0 {
-		lines = append(lines, string(currentLine))
+	// Handle manual line breaks first, then wrap each paragraph
+	var lines [][]textRun
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && runes[end] != '\n' {
+			end++
+		}
+		lines = append(lines, t.splitFormattedRunes(runes[start:end], formats[start:end], faceOf, maxWidth)...)
+		start = end + 1
 	}
 
 	return lines
 }
 
-// fitsInWidth checks if the test line fits within the maximum width.
-func (t *TextProcessor) fitsInWidth(testLine []rune, face font.Face, maxWidth int) bool {
-	testLineStr := string(testLine)
-	textWidthPx := measureStringWithSpacing(face, testLineStr, t.letterSpacing)
-	return textWidthPx <= maxWidth
-}
-
-// handleLineBreak applies line breaking rules when a character exceeds the width.
-func (t *TextProcessor) handleLineBreak(lines []string, currentLine []rune, runes []rune, i int, r rune) ([]string, []rune, int) {
-	if t.startProhibited[r] {
-		return t.handleStartProhibited(lines, currentLine, runes, i, r)
-	} else if len(currentLine) > 0 && t.endProhibited[currentLine[len(currentLine)-1]] {
-		newLines, newCurrentLine := t.handleEndProhibited(lines, currentLine, r)
-		return newLines, newCurrentLine, i + 1
-	} else {
-		newLines, newCurrentLine := t.handleWordBoundary(lines, currentLine, r)
-		return newLines, newCurrentLine, i + 1
+// splitFormattedRunes wraps a paragraph of formatted runes and regroups each line into runs.
+func (t *TextProcessor) splitFormattedRunes(runes []rune, formats []textRun, faceOf func(textRun) font.Face, maxWidth int) [][]textRun {
+	if len(runes) == 0 {
+		return nil
 	}
-}
-
-// handleStartProhibited handles characters that cannot start a line.
-func (t *TextProcessor) handleStartProhibited(lines []string, currentLine []rune, runes []rune, i int, r rune) ([]string, []rune, int) {
-	// 行頭禁則文字: Force character to stay on current line
-	currentLine = append(currentLine, r)
-	i++
 
-	// Include any consecutive prohibited characters
-	for i < len(runes) && t.startProhibited[runes[i]] {
-		currentLine = append(currentLine, runes[i])
-		i++
+	faces := make([]font.Face, len(runes))
+	for i, format := range formats {
+		faces[i] = faceOf(format)
 	}
+	measurer := &runeMeasurer{runes: runes, faces: faces, letterSpacing: t.letterSpacing}
 
-	// Finalize the current line
-	if len(currentLine) > 0 {
-		lines = append(lines, string(currentLine))
-		currentLine = []rune{}
+	// Lines partition the runes in order, so each line is the next span of its length
+	var lines [][]textRun
+	start := 0
+	for _, line := range t.splitRunes(runes, measurer, maxWidth) {
+		end := start + utf8.RuneCountInString(line)
+		lines = append(lines, groupRuns(runes[start:end], formats[start:end]))
+		start = end
 	}
 
-	return lines, currentLine, i
+	return lines
 }
 
-// handleEndProhibited handles characters that cannot end a line.
-func (t *TextProcessor) handleEndProhibited(lines []string, currentLine []rune, r rune) ([]string, []rune) {
-	// 行末禁則文字: Move prohibited character to next line
-	if len(currentLine) > 1 {
-		// Move the prohibited character to the next line
-		endChar := currentLine[len(currentLine)-1]
-		lines = append(lines, string(currentLine[:len(currentLine)-1]))
-		currentLine = []rune{endChar, r}
-	} else {
-		// Only the prohibited character on this line, break anyway
-		lines = append(lines, string(currentLine))
-		currentLine = []rune{r}
+// splitRunes wraps runes measured by the measurer into lines no wider than maxWidth.
+// Balanced wrapping rebreaks the greedy lines into the same number of more even lines.
+func (t *TextProcessor) splitRunes(runes []rune, measurer *runeMeasurer, maxWidth int) []string {
+	lines := t.splitGreedy(runes, measurer, maxWidth)
+	if t.wrap == WrapBalanced && len(lines) > 1 {
+		if balanced := t.splitBalanced(runes, measurer, maxWidth, len(lines)); balanced != nil {
+			return balanced
+		}
 	}
-
-	return lines, currentLine
+	return lines
 }
 
-// handleWordBoundary handles word boundary breaking for English text.
-func (t *TextProcessor) handleWordBoundary(lines []string, currentLine []rune, r rune) ([]string, []rune) {
-	// Handle English word boundaries
-	if len(currentLine) > 0 && isWordChar(r) {
-		// Find word boundary to avoid breaking words mid-way
-		boundaryPos := findWordBoundary(currentLine, len(currentLine)-1)
-
-		if boundaryPos >= 0 && boundaryPos < len(currentLine)-1 {
-			// Break at word boundary
-			lines = append(lines, string(currentLine[:boundaryPos+1]))
-			currentLine = append([]rune{}, currentLine[boundaryPos+1:]...)
-			currentLine = append(currentLine, r)
-		} else {
-			// No good word boundary found, break normally
-			if len(currentLine) > 0 {
-				lines = append(lines, string(currentLine))
+// splitGreedy fills each line with as many runes as fit and breaks it at the last line break
+// opportunity. Spaces at the end of a line may extend past maxWidth, since they are not drawn, and
+// start prohibited characters that do not fit hang past the end of the line (ぶら下げ) instead of
+// pushing the character before them to the next line.
+// A line without a break opportunity is broken between grapheme clusters where it overflows.
+func (t *TextProcessor) splitGreedy(runes []rune, measurer *runeMeasurer, maxWidth int) []string {
+	breaks := t.lineBreakOpportunities(runes)
+	maxWidthFixed := fixed.I(maxWidth)
+
+	var lines []string
+	for start := 0; start < len(runes); {
+		// Accumulate the width incrementally instead of re-measuring the whole line.
+		// At least one rune is kept on each line, so that wrapping always advances.
+		end, lastBreak := start, -1
+		var width fixed.Int26_6
+		for end < len(runes) {
+			width = measurer.appendWidth(width, start, end)
+			if width > maxWidthFixed && end > start && !t.hangs(runes[end]) {
+				break
+			}
+			end++
+			if end < len(runes) && breaks[end] {
+				lastBreak = end
 			}
-			currentLine = []rune{r}
-		}
-	} else {
-		// Normal character break
-		if len(currentLine) > 0 {
-			lines = append(lines, string(currentLine))
 		}
-		currentLine = []rune{r}
-	}
-
-	return lines, currentLine
-}
-
-// isWordChar determines if a character is part of an English word.
-// Used to avoid breaking words in the middle when possible.
-func isWordChar(r rune) bool {
-	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
-		(r >= '0' && r <= '9') || r == '_' || r == '-'
-}
 
-// findWordBoundary searches backwards from maxPos to find a suitable word break position.
-// Returns the index where a line break would be appropriate to avoid splitting English words.
-func findWordBoundary(runes []rune, maxPos int) int {
-	if maxPos >= len(runes) {
-		maxPos = len(runes) - 1
-	}
+		if end < len(runes) {
+			if lastBreak > start {
+				end = lastBreak
+			} else {
+				for end > start+1 && (isGraphemeExtend(runes[end]) || runes[end-1] == zeroWidthJoiner) {
+					end--
+				}
+			}
+		}
 
-	// If the character at maxPos is not a word character, break there
-	if maxPos < 0 || !isWordChar(runes[maxPos]) {
-		return maxPos
+		lines = append(lines, string(runes[start:end]))
+		start = end
 	}
 
-	// Search backwards for the beginning of the current word
-	for i := maxPos; i >= 0; i-- {
-		if i == 0 {
-			// Reached the beginning, word is too long, break at maxPos anyway
-			return maxPos
-		}
-		if !isWordChar(runes[i-1]) {
-			// Found word boundary
-			return i - 1
-		}
-	}
+	return lines
+}
 
-	return maxPos
+// hangs reports whether r may extend past the end of a line that it does not fit on.
+func (t *TextProcessor) hangs(r rune) bool {
+	return unicode.IsSpace(r) || t.startProhibited[r]
 }
 
 // calculateTextPosition determines the starting position for text rendering
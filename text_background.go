@@ -0,0 +1,137 @@
+package main
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// hasTextPanel reports whether the panel has a fill or a border.
+func hasTextPanel(panel *TextPanelConfig) bool {
+	return panel.Color != "" || panel.BorderWidth > 0
+}
+
+// blockDescent returns how far the descenders of the last line reach below the text block
+// at the given font size. The baseline of each line lies a full line height below the top
+// of the line, so the last baseline is the bottom of the block.
+func blockDescent(f *truetype.Font, fontSize float64, textConfig *TextConfig) int {
+	return newTextConfigFace(f, fontSize, textConfig).Metrics().Descent.Ceil()
+}
+
+// panelInsets returns how far the panel, its border and its shadow extend beyond the text
+// block, whose last line reaches descent pixels below it.
+func panelInsets(panel *TextPanelConfig, descent int) textEffectInsets {
+	border := int(math.Ceil(math.Max(0, panel.BorderWidth) / 2))
+	insets := textEffectInsets{
+		Left:   panel.PaddingX + border,
+		Top:    panel.PaddingY + border,
+		Right:  panel.PaddingX + border,
+		Bottom: panel.PaddingY + border,
+	}
+
+	if shadow := panel.Shadow; hasShadow(&shadow) {
+		blur := int(math.Ceil(math.Max(0, shadow.Blur)))
+		insets = insets.union(textEffectInsets{
+			Left:   panel.PaddingX + blur - shadow.OffsetX,
+			Top:    panel.PaddingY + blur - shadow.OffsetY,
+			Right:  panel.PaddingX + blur + shadow.OffsetX,
+			Bottom: panel.PaddingY + blur + shadow.OffsetY,
+		})
+	}
+	insets.Bottom += descent
+	return insets
+}
+
+// textInkBlock returns the text block narrowed vertically to the ascent of the first line and
+// the descent of the last line, so that panels are balanced around the visible glyphs.
+func textInkBlock(layout *TextLayout, block image.Rectangle, origins []image.Point) image.Rectangle {
+	if len(origins) == 0 {
+		return block
+	}
+
+	metrics := layout.Face.Metrics()
+	block.Min.Y = origins[0].Y - metrics.Ascent.Ceil()
+	block.Max.Y = origins[len(origins)-1].Y + metrics.Descent.Ceil()
+	return block
+}
+
+// drawTextPanel draws a rounded card around the text block, extended by the padding,
+// with its shadow beneath it and its border centered on the outline.
+func drawTextPanel(dst *image.RGBA, block image.Rectangle, panel *TextPanelConfig) {
+	box := image.Rect(block.Min.X-panel.PaddingX, block.Min.Y-panel.PaddingY, block.Max.X+panel.PaddingX, block.Max.Y+panel.PaddingY)
+
+	if shadow := panel.Shadow; hasShadow(&shadow) {
+		// Leave room for the blur to spread beyond the panel
+		blur := math.Max(0, shadow.Blur)
+		mask := image.NewAlpha(box.Inset(-2*int(math.Ceil(blur)) - 1))
+		unionPath(mask, box, func(p *pathWriter) {
+			p.roundedRectPath(float64(box.Min.X), float64(box.Min.Y), float64(box.Max.X), float64(box.Max.Y), panel.Radius)
+		})
+		offset := image.Point{X: shadow.OffsetX, Y: shadow.OffsetY}
+		drawEffectLayer(dst, blurMask(mask, blur/2), offset, shadow.Color, DefaultTextShadowColor)
+	}
+
+	shape := ShapeConfig{
+		Type:    ShapeRoundedRect,
+		X:       box.Min.X,
+		Y:       box.Min.Y,
+		Width:   box.Dx(),
+		Height:  box.Dy(),
+		Radius:  panel.Radius,
+		Fill:    panel.Color,
+		Opacity: 1,
+	}
+	if panel.BorderWidth > 0 {
+		shape.Stroke = panel.BorderColor
+		shape.StrokeWidth = panel.BorderWidth
+	}
+	if err := drawShape(dst, &shape); err != nil {
+		DefaultLogger.Warning("Failed to draw text panel: %v", err)
+	}
+}
+
+// lineBackgroundInsets returns how far line backgrounds extend beyond the laid out lines,
+// whose last line reaches descent pixels below them.
+func lineBackgroundInsets(background *TextLineBackgroundConfig, descent int) textEffectInsets {
+	return textEffectInsets{
+		Left:   background.PaddingX,
+		Top:    background.PaddingY,
+		Right:  background.PaddingX,
+		Bottom: background.PaddingY + descent,
+	}
+}
+
+// drawLineBackgrounds draws a box behind each line starting at its origin. Each box spans the
+// measured line width without trailing spaces and the ascent and descent of the font, extended by the padding.
+// The boxes are merged into one mask so that overlapping translucent boxes do not darken.
+func drawLineBackgrounds(dst *image.RGBA, layout *TextLayout, origins []image.Point, background *TextLineBackgroundConfig) {
+	fill, err := shapeColor(background.Color, clampUnit(background.Opacity))
+	if err != nil {
+		DefaultLogger.Warning("Failed to parse line background color '%s': %v", background.Color, err)
+		return
+	}
+
+	metrics := layout.Face.Metrics()
+	ascent, descent := metrics.Ascent.Ceil(), metrics.Descent.Ceil()
+
+	bounds := dst.Bounds()
+	mask := image.NewAlpha(bounds)
+	for i, origin := range origins {
+		width := layout.LineWidths[i]
+		if width <= 0 {
+			continue
+		}
+		box := image.Rect(
+			origin.X-background.PaddingX,
+			origin.Y-ascent-background.PaddingY,
+			origin.X+width+background.PaddingX,
+			origin.Y+descent+background.PaddingY,
+		)
+		unionPath(mask, box.Intersect(bounds), func(p *pathWriter) {
+			p.roundedRectPath(float64(box.Min.X), float64(box.Min.Y), float64(box.Max.X), float64(box.Max.Y), background.Radius)
+		})
+	}
+	draw.DrawMask(dst, bounds, image.NewUniform(fill), image.Point{}, mask, bounds.Min, draw.Over)
+}
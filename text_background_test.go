@@ -0,0 +1,302 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// newTestLineLayout lays out the lines as given at 32px. Like measureTextLayout, line widths
+// do not include trailing spaces.
+func newTestLineLayout(t *testing.T, lines ...string) *TextLayout {
+	t.Helper()
+	face := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 32})
+	layout := &TextLayout{FontSize: 32, Face: face, Lines: lines, LineHeight: 40}
+	layout.LineWidths = make([]int, len(lines))
+	for i, line := range lines {
+		layout.LineWidths[i] = measureStringWithSpacing(face, strings.TrimRight(line, " "), 0)
+		if layout.LineWidths[i] > layout.Width {
+			layout.Width = layout.LineWidths[i]
+		}
+	}
+	layout.Height = len(lines) * layout.LineHeight
+	return layout
+}
+
+// coveredSpan returns the first and last x with any coverage in row y.
+func coveredSpan(img *image.RGBA, y int) (int, int) {
+	first, last := -1, -1
+	for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+		if img.RGBAAt(x, y).A > 0 {
+			if first < 0 {
+				first = x
+			}
+			last = x
+		}
+	}
+	return first, last
+}
+
+// TestDrawLineBackgrounds_Alignment tests that each box follows its line for every line alignment.
+func TestDrawLineBackgrounds_Alignment(t *testing.T) {
+	layout := newTestLineLayout(t, "Short", "A longer line")
+	area := TextArea{X: 20, Y: 20, Width: 360, Height: 120}
+	background := &TextLineBackgroundConfig{Color: "#FF0000", PaddingX: 10, PaddingY: 2, Opacity: 1}
+	ascent := layout.Face.Metrics().Ascent.Ceil()
+
+	for _, lineAlignment := range []string{"left", "center", "right"} {
+		t.Run(lineAlignment, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 400, 160))
+			origins := layout.lineOrigins(area, "top-center", lineAlignment)
+			drawLineBackgrounds(img, layout, origins, background)
+
+			for i, origin := range origins {
+				first, last := coveredSpan(img, origin.Y-ascent/2)
+				expectedFirst := origin.X - background.PaddingX
+				expectedLast := origin.X + layout.LineWidths[i] + background.PaddingX - 1
+				if first != expectedFirst || last != expectedLast {
+					t.Errorf("Line %d: expected box from %d to %d, got %d to %d", i, expectedFirst, expectedLast, first, last)
+				}
+			}
+		})
+	}
+}
+
+// TestDrawLineBackgrounds_TrailingSpace tests that trailing spaces do not widen the box.
+func TestDrawLineBackgrounds_TrailingSpace(t *testing.T) {
+	background := &TextLineBackgroundConfig{Color: "#FF0000", Opacity: 1}
+	area := TextArea{X: 20, Y: 20, Width: 360, Height: 60}
+
+	spans := make([][2]int, 2)
+	for i, line := range []string{"Marker", "Marker "} {
+		layout := newTestLineLayout(t, line)
+		img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+		origins := layout.lineOrigins(area, "top-left", "left")
+		drawLineBackgrounds(img, layout, origins, background)
+		spans[i][0], spans[i][1] = coveredSpan(img, origins[0].Y-5)
+	}
+
+	if spans[0] != spans[1] {
+		t.Errorf("Expected the same box with and without trailing space, got %v and %v", spans[0], spans[1])
+	}
+}
+
+// TestDrawLineBackgrounds_OverlappingOpacity tests that overlapping translucent boxes are drawn once.
+func TestDrawLineBackgrounds_OverlappingOpacity(t *testing.T) {
+	layout := newTestLineLayout(t, "Overlap", "Overlap")
+	area := TextArea{X: 20, Y: 20, Width: 360, Height: 120}
+	background := &TextLineBackgroundConfig{Color: "#FF0000", PaddingX: 4, PaddingY: 12, Opacity: 0.5}
+
+	img := newFilledRGBA(400, 160, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+	origins := layout.lineOrigins(area, "top-left", "left")
+	drawLineBackgrounds(img, layout, origins, background)
+
+	// The lower padding of the first box overlaps the upper padding of the second box
+	x := origins[0].X + 5
+	single := img.RGBAAt(x, origins[0].Y-10)
+	overlap := img.RGBAAt(x, origins[0].Y+(origins[1].Y-origins[0].Y)/2-10)
+	if single != overlap {
+		t.Errorf("Expected the same color where boxes overlap, got %v and %v", single, overlap)
+	}
+	if absDiff(single.G, 128) > 2 {
+		t.Errorf("Expected half transparent red over white, got %v", single)
+	}
+}
+
+// TestRenderTextElement_LineBackgroundStaysInArea tests that the padding is kept inside the area.
+func TestRenderTextElement_LineBackgroundStaysInArea(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 120
+	textConfig.MinSize = 8
+	textConfig.Overflow = OverflowShrink
+	textConfig.Area = TextArea{X: 20, Y: 20, Width: 260, Height: 100}
+	textConfig.LineBackground = TextLineBackgroundConfig{Color: "#FFE066", PaddingX: 16, PaddingY: 4, Radius: 6, Opacity: 1}
+
+	img := newFilledRGBA(300, 140, white)
+	if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "Highlighted title", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	ink := inkBounds(img, white)
+	area := textConfig.Area
+	if ink.Empty() || ink.Min.X < area.X || ink.Max.X > area.X+area.Width {
+		t.Errorf("Expected boxes horizontally within area %+v, got ink at %v", area, ink)
+	}
+}
+
+// TestMergeConfigsWithSettings_LineBackground tests merging line backgrounds from global settings and front matter.
+func TestMergeConfigsWithSettings_LineBackground(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{
+			LineBackground: &TextLineBackgroundSettings{Color: stringPtr("#FFE066"), Radius: float64Ptr(6)},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			LineBackground: &TextLineBackgroundSettings{PaddingX: intPtr(20), Opacity: float64Ptr(0.8)},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	expected := TextLineBackgroundConfig{
+		Color:    "#FFE066",
+		PaddingX: 20,
+		PaddingY: DefaultLineBackgroundPaddingY,
+		Radius:   6,
+		Opacity:  0.8,
+	}
+	if config.Title.LineBackground != expected {
+		t.Errorf("Expected line background %+v, got %+v", expected, config.Title.LineBackground)
+	}
+	if config.Description.LineBackground.Color != "" {
+		t.Errorf("Expected no description line background, got %+v", config.Description.LineBackground)
+	}
+}
+
+// TestPanelInsets tests the extent of the panel padding, border and shadow.
+func TestPanelInsets(t *testing.T) {
+	panel := &TextPanelConfig{PaddingX: 10, PaddingY: 6, BorderWidth: 3}
+	expected := textEffectInsets{Left: 12, Top: 8, Right: 12, Bottom: 8}
+	if got := panelInsets(panel, 0); got != expected {
+		t.Errorf("Expected insets %+v, got %+v", expected, got)
+	}
+
+	panel.Shadow = TextShadowConfig{OffsetY: 8, Blur: 4}
+	expected = textEffectInsets{Left: 14, Top: 8, Right: 14, Bottom: 18}
+	if got := panelInsets(panel, 0); got != expected {
+		t.Errorf("Expected insets %+v, got %+v", expected, got)
+	}
+
+	// The descent of the last line moves the bottom of the panel and its shadow
+	expected = textEffectInsets{Left: 14, Top: 8, Right: 14, Bottom: 27}
+	if got := panelInsets(panel, 9); got != expected {
+		t.Errorf("Expected insets %+v, got %+v", expected, got)
+	}
+}
+
+// TestRenderTextElement_PanelFollowsText tests that the panel is sized to the text rather than the area.
+func TestRenderTextElement_PanelFollowsText(t *testing.T) {
+	dark := color.RGBA{R: 20, G: 20, B: 40, A: 255}
+	renderer := NewImageRenderer()
+	font := parseTestFont(t)
+
+	panelBounds := func(text string) image.Rectangle {
+		textConfig := newTestLayoutConfig()
+		textConfig.Size = 40
+		textConfig.Overflow = OverflowClip
+		textConfig.BlockPosition = "middle-center"
+		textConfig.Area = TextArea{X: 20, Y: 20, Width: 560, Height: 200}
+		textConfig.Panel = TextPanelConfig{Color: "#FFFFFF", PaddingX: 24, PaddingY: 16, Radius: 12}
+
+		img := newFilledRGBA(600, 240, dark)
+		if err := renderer.RenderTextElement(img, font, textConfig, text, false, "title"); err != nil {
+			t.Fatalf("RenderTextElement failed: %v", err)
+		}
+		return inkBounds(img, dark)
+	}
+
+	short := panelBounds("Hi")
+	long := panelBounds("A much longer title")
+
+	if short.Empty() || short.Dx() >= long.Dx() {
+		t.Errorf("Expected a smaller panel for a short title, got %v and %v", short, long)
+	}
+	if short.Dx() > 200 || short.Dy() > 120 {
+		t.Errorf("Expected the short panel to fit the text, got %v", short)
+	}
+
+	// The centered panel is balanced around the center of the area
+	if center := (short.Min.X + short.Max.X) / 2; center < 296 || center > 304 {
+		t.Errorf("Expected the panel centered at x=300, got %v", short)
+	}
+	if !long.In(image.Rect(20, 20, 580, 220)) {
+		t.Errorf("Expected the panel within the area, got %v", long)
+	}
+}
+
+// TestRenderTextElement_PanelStaysInAreaAtBottom tests that the descent of the last line is
+// kept inside the area when the block sits on its bottom edge.
+func TestRenderTextElement_PanelStaysInAreaAtBottom(t *testing.T) {
+	dark := color.RGBA{R: 20, G: 20, B: 40, A: 255}
+	for _, position := range []string{"bottom-left", "bottom-center", "bottom-right"} {
+		textConfig := newTestLayoutConfig()
+		textConfig.Size = 40
+		textConfig.Overflow = OverflowClip
+		textConfig.BlockPosition = position
+		textConfig.Area = TextArea{X: 20, Y: 20, Width: 560, Height: 120}
+		textConfig.Panel = TextPanelConfig{Color: "#FFFFFF", PaddingX: 16, PaddingY: 8}
+
+		img := newFilledRGBA(600, 200, dark)
+		if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "Typography", false, "title"); err != nil {
+			t.Fatalf("RenderTextElement failed: %v", err)
+		}
+
+		ink := inkBounds(img, dark)
+		if ink.Empty() || ink.Max.Y > 140 {
+			t.Errorf("%s: expected the panel within the area bottom at y=140, got %v", position, ink)
+		}
+		if ink.Max.Y < 136 {
+			t.Errorf("%s: expected the panel on the area bottom at y=140, got %v", position, ink)
+		}
+	}
+}
+
+// TestRenderTextElement_LineBackgroundStaysInAreaAtBottom tests that the box of the last line,
+// which spans its descent, is kept inside the area when the block sits on its bottom edge.
+func TestRenderTextElement_LineBackgroundStaysInAreaAtBottom(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 40
+	textConfig.Overflow = OverflowClip
+	textConfig.BlockPosition = "bottom-left"
+	textConfig.Area = TextArea{X: 10, Y: 10, Width: 200, Height: 60}
+	textConfig.LineBackground = TextLineBackgroundConfig{Color: "#FFE066", PaddingX: 4, PaddingY: 2, Opacity: 1}
+
+	img := newFilledRGBA(240, 100, white)
+	if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "Typography", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	ink := inkBounds(img, white)
+	if ink.Empty() || ink.Max.Y > 70 {
+		t.Errorf("Expected the box within the area bottom at y=70, got %v", ink)
+	}
+}
+
+// TestMergeConfigsWithSettings_Panel tests merging panels from global settings and front matter.
+func TestMergeConfigsWithSettings_Panel(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{
+			Panel: &TextPanelSettings{
+				Color:  stringPtr("#FFFFFF"),
+				Shadow: &TextShadowSettings{OffsetY: intPtr(8), Blur: float64Ptr(16)},
+			},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			Panel: &TextPanelSettings{BorderWidth: float64Ptr(2), Shadow: &TextShadowSettings{Color: stringPtr("#00000040")}},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	panel := config.Title.Panel
+
+	if panel.Color != "#FFFFFF" || panel.BorderWidth != 2 || panel.BorderColor != DefaultPanelBorderColor {
+		t.Errorf("Unexpected merged panel %+v", panel)
+	}
+	if panel.PaddingX != DefaultPanelPaddingX || panel.Radius != DefaultPanelRadius {
+		t.Errorf("Expected default padding and radius, got %+v", panel)
+	}
+	if panel.Shadow != (TextShadowConfig{OffsetY: 8, Blur: 16, Color: "#00000040"}) {
+		t.Errorf("Unexpected merged panel shadow %+v", panel.Shadow)
+	}
+	if hasTextPanel(&config.Description.Panel) {
+		t.Errorf("Expected no description panel, got %+v", config.Description.Panel)
+	}
+}
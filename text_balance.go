@@ -0,0 +1,76 @@
+package main
+
+import (
+	"golang.org/x/image/math/fixed"
+)
+
+// splitBalanced breaks runes into exactly lineCount lines no wider than maxWidth, choosing the
+// breaks that minimize the sum of the squared space left at the end of each line. The last line
+// is included in the cost, so it does not end up holding a single word or a few kana.
+// Lines break at the same opportunities as greedy wrapping, and trailing spaces and hanging
+// start prohibited characters are not counted.
+// It returns nil when the runes cannot be broken into lineCount lines, for example when
+// a word is wider than maxWidth.
+func (t *TextProcessor) splitBalanced(runes []rune, measurer *runeMeasurer, maxWidth int, lineCount int) []string {
+	n := len(runes)
+	breaks := t.lineBreakOpportunities(runes)
+	maxWidthFixed := fixed.I(maxWidth)
+
+	// cost[k][i] is the lowest cost of breaking the first i runes into k lines,
+	// and start[k][i] is where the last of those lines starts
+	const unreachable = int64(-1)
+	cost := make([][]int64, lineCount+1)
+	start := make([][]int, lineCount+1)
+	for k := range cost {
+		cost[k] = make([]int64, n+1)
+		start[k] = make([]int, n+1)
+		for i := range cost[k] {
+			cost[k][i] = unreachable
+		}
+	}
+	cost[0][0] = 0
+
+	for from := 0; from < n; from++ {
+		if from > 0 && !breaks[from] {
+			continue
+		}
+
+		var width, visibleWidth fixed.Int26_6
+		for to := from + 1; to <= n; to++ {
+			width = measurer.appendWidth(width, from, to-1)
+			if !t.hangs(runes[to-1]) {
+				visibleWidth = width
+			}
+			if visibleWidth > maxWidthFixed {
+				break
+			}
+			if to < n && !breaks[to] {
+				continue
+			}
+
+			slack := int64(maxWidthFixed - visibleWidth)
+			for k := 1; k <= lineCount; k++ {
+				if cost[k-1][from] == unreachable {
+					continue
+				}
+				candidate := cost[k-1][from] + slack*slack
+				if cost[k][to] == unreachable || candidate < cost[k][to] {
+					cost[k][to] = candidate
+					start[k][to] = from
+				}
+			}
+		}
+	}
+
+	if cost[lineCount][n] == unreachable {
+		return nil
+	}
+
+	lines := make([]string, lineCount)
+	end := n
+	for k := lineCount; k > 0; k-- {
+		lines[k-1] = string(runes[start[k][end]:end])
+		end = start[k][end]
+	}
+	return lines
+}
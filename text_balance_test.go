@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// TestTextProcessor_SplitText_Balanced tests that balanced wrapping evens out the lines
+// of the greedy wrapping without adding lines.
+func TestTextProcessor_SplitText_Balanced(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	face := newTextConfigFace(parseTestFont(t), 32, textConfig)
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+
+	tests := []struct {
+		name     string
+		text     string
+		widowEnd string // Text the greedy last line consists of
+	}{
+		{"english widow", "The quick brown fox jumps over the lazy dog", "dog"},
+		{"japanese widow", "今日はとても良い天気ですね、明日も晴れるでしょう。", "う。"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The area fits all but the end of the text on the first line
+			head := strings.TrimSuffix(tt.text, tt.widowEnd)
+			maxWidth := measureStringWithSpacing(face, head, 0) + 2
+
+			greedy := NewTextProcessor(startProhibited, endProhibited, 0)
+			greedyLines := greedy.SplitText(tt.text, face, maxWidth)
+			if len(greedyLines) != 2 || greedyLines[1] != tt.widowEnd {
+				t.Fatalf("Expected a greedy widow %q, got %q", tt.widowEnd, greedyLines)
+			}
+
+			balanced := NewTextProcessor(startProhibited, endProhibited, 0)
+			balanced.wrap = WrapBalanced
+			lines := balanced.SplitText(tt.text, face, maxWidth)
+
+			if len(lines) != len(greedyLines) || strings.Join(lines, "") != tt.text {
+				t.Fatalf("Expected the text in %d lines, got %q", len(greedyLines), lines)
+			}
+			first := measureStringWithSpacing(face, lines[0], 0)
+			last := measureStringWithSpacing(face, lines[1], 0)
+			if first > maxWidth || last > maxWidth {
+				t.Errorf("Expected the lines to fit %dpx, got %q", maxWidth, lines)
+			}
+			if last < first/2 {
+				t.Errorf("Expected lines of similar width, got %q (%dpx and %dpx)", lines, first, last)
+			}
+			for _, line := range lines[1:] {
+				if r := []rune(line)[0]; startProhibited[r] || unicode.IsSpace(r) {
+					t.Errorf("Expected no line to start with %q, got %q", r, lines)
+				}
+			}
+		})
+	}
+}
+
+// TestTextProcessor_SplitText_BalancedFallsBack tests that text without enough break
+// opportunities keeps the greedy lines.
+func TestTextProcessor_SplitText_BalancedFallsBack(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	face := newTextConfigFace(parseTestFont(t), 32, textConfig)
+	text := "Supercalifragilisticexpialidocious"
+
+	greedy := NewTextProcessor(nil, nil, 0)
+	balanced := NewTextProcessor(nil, nil, 0)
+	balanced.wrap = WrapBalanced
+
+	expected := greedy.SplitText(text, face, 200)
+	if got := balanced.SplitText(text, face, 200); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected the greedy lines %q, got %q", expected, got)
+	}
+}
+
+// TestMergeConfigsWithSettings_Wrap tests merging the wrapping mode.
+func TestMergeConfigsWithSettings_Wrap(t *testing.T) {
+	global := &ConfigSettings{Title: &TextSettings{Wrap: stringPtr(WrapBalanced)}}
+	fm := &OGPFrontMatter{Title: &TextConfigOverride{Wrap: stringPtr(WrapGreedy)}}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, nil)
+	if config.Title.Wrap != WrapBalanced || config.Description.Wrap != WrapGreedy {
+		t.Errorf("Unexpected wrapping %s and %s", config.Title.Wrap, config.Description.Wrap)
+	}
+
+	config = NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	if config.Title.Wrap != WrapGreedy {
+		t.Errorf("Expected the front matter wrapping, got %s", config.Title.Wrap)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// textEffectInsets holds how far text effects extend beyond the glyphs on each side.
+type textEffectInsets struct {
+	Left, Top, Right, Bottom int
+}
+
+// hasTextEffects reports whether any stroke, shadow or glow is configured.
+func hasTextEffects(textConfig *TextConfig) bool {
+	return textConfig.Stroke.Width > 0 || textConfig.Glow.Radius > 0 || hasShadow(&textConfig.Shadow)
+}
+
+// hasShadow reports whether the shadow is enabled by an offset or blur.
+func hasShadow(shadow *TextShadowConfig) bool {
+	return shadow.OffsetX != 0 || shadow.OffsetY != 0 || shadow.Blur > 0
+}
+
+// effectInsets returns the extent of the configured effects beyond the glyphs.
+// Glow and shadow are cast by the stroked outline, so they extend beyond the stroke.
+func effectInsets(textConfig *TextConfig) textEffectInsets {
+	stroke := math.Max(0, textConfig.Stroke.Width)
+	insets := [4]float64{stroke, stroke, stroke, stroke}
+
+	extend := func(side int, extent float64) {
+		if extent > insets[side] {
+			insets[side] = extent
+		}
+	}
+
+	if glow := textConfig.Glow.Radius; glow > 0 {
+		for side := range insets {
+			extend(side, stroke+glow)
+		}
+	}
+
+	if shadow := textConfig.Shadow; hasShadow(&shadow) {
+		blur := math.Max(0, shadow.Blur)
+		dx, dy := float64(shadow.OffsetX), float64(shadow.OffsetY)
+		extend(0, stroke+blur-dx)
+		extend(1, stroke+blur-dy)
+		extend(2, stroke+blur+dx)
+		extend(3, stroke+blur+dy)
+	}
+
+	return textEffectInsets{
+		Left:   int(math.Ceil(insets[0])),
+		Top:    int(math.Ceil(insets[1])),
+		Right:  int(math.Ceil(insets[2])),
+		Bottom: int(math.Ceil(insets[3])),
+	}
+}
+
+// union returns the larger of the two insets on each side.
+func (insets textEffectInsets) union(other textEffectInsets) textEffectInsets {
+	larger := func(a, b int) int {
+		if a > b {
+			return a
+		}
+		return b
+	}
+	return textEffectInsets{
+		Left:   larger(insets.Left, other.Left),
+		Top:    larger(insets.Top, other.Top),
+		Right:  larger(insets.Right, other.Right),
+		Bottom: larger(insets.Bottom, other.Bottom),
+	}
+}
+
+// inset returns the area reduced by the insets, so that text laid out in it keeps its effects inside the area.
+func (insets textEffectInsets) inset(area TextArea) TextArea {
+	area.X += insets.Left
+	area.Y += insets.Top
+	area.Width -= insets.Left + insets.Right
+	area.Height -= insets.Top + insets.Bottom
+	if area.Width < 1 {
+		area.Width = 1
+	}
+	if area.Height < 1 {
+		area.Height = 1
+	}
+	return area
+}
+
+// drawTextEffects draws the shadow, glow and stroke of the glyphs in mask onto dst.
+// The fill is drawn by the caller on top of the effects.
+func drawTextEffects(dst *image.RGBA, mask *image.Alpha, textConfig *TextConfig) {
+	outline := mask
+	if textConfig.Stroke.Width > 0 {
+		outline = dilateMask(mask, textConfig.Stroke.Width)
+	}
+
+	if shadow := textConfig.Shadow; hasShadow(&shadow) {
+		shadowMask := blurMask(outline, shadow.Blur/2)
+		offset := image.Point{X: shadow.OffsetX, Y: shadow.OffsetY}
+		drawEffectLayer(dst, shadowMask, offset, shadow.Color, DefaultTextShadowColor)
+	}
+
+	if glow := textConfig.Glow.Radius; glow > 0 {
+		// Spreading the outline before blurring keeps the glow dense close to the glyphs
+		glowMask := blurMask(dilateMask(outline, glow/3), glow/3)
+		drawEffectLayer(dst, glowMask, image.Point{}, textConfig.Glow.Color, DefaultTextGlowColor)
+	}
+
+	if textConfig.Stroke.Width > 0 {
+		drawEffectLayer(dst, outline, image.Point{}, textConfig.Stroke.Color, DefaultTextStrokeColor)
+	}
+}
+
+// drawEffectLayer fills the mask, shifted by offset, with a color over dst.
+func drawEffectLayer(dst *image.RGBA, mask *image.Alpha, offset image.Point, hex, defaultHex string) {
+	c, err := parseHexColor(hex)
+	if err != nil {
+		DefaultLogger.Warning("Failed to parse text effect color '%s', using %s: %v", hex, defaultHex, err)
+		c, _ = parseHexColor(defaultHex)
+	}
+	src := image.NewUniform(color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A})
+
+	r := mask.Bounds().Add(offset)
+	draw.DrawMask(dst, r, src, image.Point{}, mask, mask.Bounds().Min, draw.Over)
+}
+
+// blurMask returns a gaussian blurred copy of the mask with the same bounds.
+func blurMask(mask *image.Alpha, sigma float64) *image.Alpha {
+	if sigma <= 0 {
+		return mask
+	}
+
+	blurred := imaging.Blur(mask, sigma)
+	result := image.NewAlpha(mask.Bounds())
+	for y := 0; y < result.Rect.Dy(); y++ {
+		row := blurred.Pix[y*blurred.Stride:]
+		for x := 0; x < result.Rect.Dx(); x++ {
+			result.Pix[y*result.Stride+x] = row[x*4+3]
+		}
+	}
+	return result
+}
+
+// dilateMask grows the coverage of the mask by radius pixels with a disk shaped structuring element.
+// Anti-aliased edges are preserved because every pixel takes the maximum coverage within the disk.
+func dilateMask(mask *image.Alpha, radius float64) *image.Alpha {
+	if radius <= 0 {
+		return mask
+	}
+
+	bounds := mask.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	result := image.NewAlpha(bounds)
+	rowMax := make([]uint8, width)
+	reach := int(math.Ceil(radius))
+
+	for dy := -reach; dy <= reach; dy++ {
+		halfWidth := int(math.Round(math.Sqrt(math.Max(0, radius*radius-float64(dy*dy)))))
+		if float64(dy*dy) > radius*radius+radius {
+			continue
+		}
+		for y := 0; y < height; y++ {
+			sy := y + dy
+			if sy < 0 || sy >= height {
+				continue
+			}
+			slidingMax(mask.Pix[sy*mask.Stride:sy*mask.Stride+width], rowMax, halfWidth)
+			out := result.Pix[y*result.Stride : y*result.Stride+width]
+			for x, v := range rowMax {
+				if v > out[x] {
+					out[x] = v
+				}
+			}
+		}
+	}
+	return result
+}
+
+// slidingMax stores in dst the maximum of src within halfWidth of each position.
+func slidingMax(src, dst []uint8, halfWidth int) {
+	// Monotonic queue of indices with decreasing values
+	queue := make([]int, 0, 2*halfWidth+1)
+	next := 0
+	for x := range dst {
+		for ; next < len(src) && next <= x+halfWidth; next++ {
+			for len(queue) > 0 && src[queue[len(queue)-1]] <= src[next] {
+				queue = queue[:len(queue)-1]
+			}
+			queue = append(queue, next)
+		}
+		for queue[0] < x-halfWidth {
+			queue = queue[1:]
+		}
+		dst[x] = src[queue[0]]
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// inkBounds returns the bounds of the pixels that differ from the background color.
+func inkBounds(img *image.RGBA, background color.RGBA) image.Rectangle {
+	var ink image.Rectangle
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if img.RGBAAt(x, y) != background {
+				ink = ink.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return ink
+}
+
+// newEffectTestConfig returns a white top-left aligned title that is invisible on a white background.
+func newEffectTestConfig() *TextConfig {
+	textConfig := newTestLayoutConfig()
+	textConfig.Color = "#FFFFFF"
+	textConfig.Size = 40
+	textConfig.BlockPosition = "top-left"
+	textConfig.Overflow = OverflowClip
+	textConfig.Area = TextArea{X: 20, Y: 20, Width: 260, Height: 100}
+	return textConfig
+}
+
+// TestDilateMask tests that a single pixel grows into a disk of the given radius.
+func TestDilateMask(t *testing.T) {
+	mask := image.NewAlpha(image.Rect(0, 0, 11, 11))
+	mask.SetAlpha(5, 5, color.Alpha{A: 200})
+
+	dilated := dilateMask(mask, 2)
+
+	for _, p := range []image.Point{{5, 5}, {7, 5}, {5, 3}, {6, 6}} {
+		if got := dilated.AlphaAt(p.X, p.Y).A; got != 200 {
+			t.Errorf("Expected coverage 200 at %v, got %d", p, got)
+		}
+	}
+	for _, p := range []image.Point{{8, 5}, {7, 7}, {0, 0}} {
+		if got := dilated.AlphaAt(p.X, p.Y).A; got != 0 {
+			t.Errorf("Expected no coverage at %v, got %d", p, got)
+		}
+	}
+}
+
+// TestEffectInsets tests the extent of combined stroke, shadow and glow effects.
+func TestEffectInsets(t *testing.T) {
+	textConfig := &TextConfig{
+		Stroke: TextStrokeConfig{Width: 2},
+		Shadow: TextShadowConfig{OffsetX: 4, OffsetY: 6, Blur: 2},
+	}
+	expected := textEffectInsets{Left: 2, Top: 2, Right: 8, Bottom: 10}
+	if got := effectInsets(textConfig); got != expected {
+		t.Errorf("Expected insets %+v, got %+v", expected, got)
+	}
+
+	textConfig.Glow.Radius = 3.5
+	expected = textEffectInsets{Left: 6, Top: 6, Right: 8, Bottom: 10}
+	if got := effectInsets(textConfig); got != expected {
+		t.Errorf("Expected insets %+v, got %+v", expected, got)
+	}
+
+	area := expected.inset(TextArea{X: 10, Y: 10, Width: 100, Height: 50})
+	if area != (TextArea{X: 16, Y: 16, Width: 86, Height: 34}) {
+		t.Errorf("Unexpected inset area %+v", area)
+	}
+
+	if hasTextEffects(&TextConfig{}) {
+		t.Error("Expected no effects for a zero config")
+	}
+}
+
+// TestRenderTextElement_Stroke tests that the stroke is drawn around white text on a white background.
+func TestRenderTextElement_Stroke(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	renderer := NewImageRenderer()
+	font := parseTestFont(t)
+
+	textConfig := newEffectTestConfig()
+	img := newFilledRGBA(300, 140, white)
+	if err := renderer.RenderTextElement(img, font, textConfig, "Stroke", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+	if ink := inkBounds(img, white); !ink.Empty() {
+		t.Fatalf("Expected white text without effects to be invisible, got ink at %v", ink)
+	}
+
+	textConfig.Stroke = TextStrokeConfig{Width: 3, Color: "#FF0000"}
+	img = newFilledRGBA(300, 140, white)
+	if err := renderer.RenderTextElement(img, font, textConfig, "Stroke", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	red := 0
+	for i := 0; i < len(img.Pix); i += 4 {
+		if img.Pix[i] == 255 && img.Pix[i+1] == 0 && img.Pix[i+2] == 0 {
+			red++
+		}
+	}
+	if red == 0 {
+		t.Error("Expected solid stroke pixels around the text")
+	}
+}
+
+// TestRenderTextElement_ShadowOffset tests that the shadow is the text shifted by the offset.
+func TestRenderTextElement_ShadowOffset(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	renderer := NewImageRenderer()
+	font := parseTestFont(t)
+
+	textConfig := newEffectTestConfig()
+	textConfig.Color = "#000000"
+	plain := newFilledRGBA(300, 140, white)
+	if err := renderer.RenderTextElement(plain, font, textConfig, "Shadow", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+	textBounds := inkBounds(plain, white)
+
+	// The offset is down and right, so the layout position is unchanged
+	textConfig.Color = "#FFFFFF"
+	textConfig.Shadow = TextShadowConfig{OffsetX: 10, OffsetY: 6, Color: "#000000"}
+	shadowed := newFilledRGBA(300, 140, white)
+	if err := renderer.RenderTextElement(shadowed, font, textConfig, "Shadow", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+	shadowBounds := inkBounds(shadowed, white)
+
+	if expected := textBounds.Max.Add(image.Point{X: 10, Y: 6}); shadowBounds.Max != expected {
+		t.Errorf("Expected shadow to end at %v, got %v (text %v)", expected, shadowBounds.Max, textBounds)
+	}
+}
+
+// TestRenderTextElement_EffectsStayInArea tests that shrink-to-fit leaves room for the effects.
+func TestRenderTextElement_EffectsStayInArea(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	renderer := NewImageRenderer()
+	font := parseTestFont(t)
+
+	textConfig := newEffectTestConfig()
+	textConfig.Color = "#000000"
+	textConfig.Size = 120
+	textConfig.MinSize = 8
+	textConfig.Overflow = OverflowShrink
+	textConfig.Stroke = TextStrokeConfig{Width: 6, Color: "#FF0000"}
+	textConfig.Shadow = TextShadowConfig{OffsetX: 8, OffsetY: 8, Blur: 4, Color: "#0000FF"}
+	textConfig.Glow = TextGlowConfig{Radius: 5, Color: "#00FF00"}
+
+	img := newFilledRGBA(300, 140, white)
+	if err := renderer.RenderTextElement(img, font, textConfig, "Fits with effects", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	area := textConfig.Area
+	ink := inkBounds(img, white)
+	if ink.Empty() {
+		t.Fatal("Expected rendered text")
+	}
+	if !ink.In(image.Rect(area.X, area.Y, area.X+area.Width, area.Y+area.Height)) {
+		t.Errorf("Expected text and effects within area %+v, got ink at %v", area, ink)
+	}
+}
+
+// TestMergeConfigsWithSettings_TextEffects tests merging effects from global settings and front matter.
+func TestMergeConfigsWithSettings_TextEffects(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{
+			Stroke: &TextStrokeSettings{Width: float64Ptr(2)},
+			Shadow: &TextShadowSettings{OffsetX: intPtr(3), Blur: float64Ptr(4)},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			Stroke: &TextStrokeSettings{Color: stringPtr("#FFFFFF")},
+			Glow:   &TextGlowSettings{Radius: float64Ptr(6)},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	title := config.Title
+
+	if title.Stroke != (TextStrokeConfig{Width: 2, Color: "#FFFFFF"}) {
+		t.Errorf("Unexpected stroke %+v", title.Stroke)
+	}
+	if title.Shadow != (TextShadowConfig{OffsetX: 3, Blur: 4, Color: DefaultTextShadowColor}) {
+		t.Errorf("Unexpected shadow %+v", title.Shadow)
+	}
+	if title.Glow != (TextGlowConfig{Radius: 6, Color: DefaultTextGlowColor}) {
+		t.Errorf("Unexpected glow %+v", title.Glow)
+	}
+	if config.Description.Stroke.Width != 0 {
+		t.Errorf("Expected description without stroke, got %+v", config.Description.Stroke)
+	}
+}
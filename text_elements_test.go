@@ -0,0 +1,132 @@
+package main
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+// TestTextElementsMerge tests the 4-level merge of named text elements.
+func TestTextElementsMerge(t *testing.T) {
+	globalSettings := &ConfigSettings{
+		Texts: map[string]*TextSettings{
+			"site": {
+				Content: stringPtr("My Blog"),
+				Size:    float64Ptr(28),
+				Area:    &TextAreaSettings{X: intPtr(50), Y: intPtr(560), Width: intPtr(400), Height: intPtr(40)},
+			},
+			"author": {
+				Content: stringPtr("{{.Fields.author}}"),
+				Color:   stringPtr("#333333"),
+			},
+		},
+	}
+	typeSettings := &ConfigSettings{
+		Texts: map[string]*TextSettings{
+			"site":    {Color: stringPtr("#FF0000")},
+			"reading": {Content: stringPtr("5 min read")},
+		},
+	}
+	frontMatter := &OGPFrontMatter{
+		Texts: map[string]*TextConfigOverride{
+			"author": {Visible: boolPtr(false)},
+			"site":   {Size: float64Ptr(32)},
+			"date":   {Content: stringPtr("{{.Date}}")},
+		},
+	}
+
+	merger := NewConfigMerger()
+	config := merger.MergeConfigsWithSettings(getDefaultConfig(), globalSettings, typeSettings, frontMatter)
+
+	if len(config.Texts) != 4 {
+		t.Fatalf("Expected 4 text elements, got %d", len(config.Texts))
+	}
+
+	site := config.Texts["site"]
+	if *site.Content != "My Blog" || site.Size != 32 || site.Color != "#FF0000" || site.Area.Y != 560 {
+		t.Errorf("Unexpected site element: content %q size %.1f color %s area %+v", *site.Content, site.Size, site.Color, site.Area)
+	}
+	if !site.Visible || site.LineHeight != DefaultLineHeight || site.Overflow != OverflowClip {
+		t.Errorf("Expected site element to keep text element defaults, got %+v", site)
+	}
+
+	author := config.Texts["author"]
+	if author.Visible || author.Color != "#333333" {
+		t.Errorf("Expected hidden author element with global color, got visible %t color %s", author.Visible, author.Color)
+	}
+
+	date := config.Texts["date"]
+	if !date.Visible || date.Area != (TextArea{}) || date.Size != DefaultDescriptionFontSize {
+		t.Errorf("Expected date element with defaults, got %+v", date)
+	}
+}
+
+// TestTextElementsDoNotModifyBaseConfig tests that front matter text overrides do not leak into the base config.
+func TestTextElementsDoNotModifyBaseConfig(t *testing.T) {
+	base := getDefaultConfig()
+	element := newTextElementConfig()
+	element.Content = stringPtr("Base")
+	base.Texts = map[string]TextConfig{"site": element}
+
+	merger := NewConfigMerger()
+	merged := merger.MergeConfigs(base, &OGPFrontMatter{
+		Texts: map[string]*TextConfigOverride{
+			"site": {Content: stringPtr("Override"), Z: intPtr(3)},
+		},
+	})
+
+	if *merged.Texts["site"].Content != "Override" || merged.Texts["site"].Z != 3 {
+		t.Errorf("Unexpected merged element: %+v", merged.Texts["site"])
+	}
+	if *base.Texts["site"].Content != "Base" || base.Texts["site"].Z != 0 {
+		t.Errorf("Base config text element was modified: %+v", base.Texts["site"])
+	}
+}
+
+// TestArticleProcessor_DetermineTextElements tests content template evaluation for text elements.
+func TestArticleProcessor_DetermineTextElements(t *testing.T) {
+	config := getDefaultConfig()
+	author := newTextElementConfig()
+	author.Content = stringPtr("by {{.Fields.author}}")
+	hidden := newTextElementConfig()
+	hidden.Content = stringPtr("hidden")
+	hidden.Visible = false
+	empty := newTextElementConfig()
+	config.Texts = map[string]TextConfig{"author": author, "hidden": hidden, "empty": empty}
+
+	fm := &FrontMatter{
+		Title:  "Title",
+		Fields: map[string]interface{}{"author": "Jane"},
+	}
+
+	ap := &ArticleProcessor{templateProcessor: NewTemplateProcessor()}
+	texts := ap.determineTextElements(fm, config)
+
+	if len(texts) != 1 || texts["author"] != "by Jane" {
+		t.Errorf("Expected only author text 'by Jane', got %v", texts)
+	}
+}
+
+// TestArticleProcessor_TextElementLayers tests that text elements become scene layers in name order.
+func TestArticleProcessor_TextElementLayers(t *testing.T) {
+	config := getDefaultConfig()
+	config.Texts = map[string]TextConfig{
+		"site":   newTextElementConfig(),
+		"author": newTextElementConfig(),
+		"date":   newTextElementConfig(),
+	}
+	texts := map[string]string{"site": "My Blog", "author": "Jane"}
+
+	ap := &ArticleProcessor{imageRenderer: NewImageRenderer()}
+	background := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	scene := ap.buildScene(background, nil, config, &ArticleContent{Title: "Title", Texts: texts}, "", false)
+
+	var names []string
+	for _, layer := range scene.Layers() {
+		names = append(names, layer.Name)
+	}
+	expected := "background,title,text 'author',text 'site'"
+	if got := strings.Join(names, ","); got != expected {
+		t.Errorf("Expected layers %s, got %s", expected, got)
+	}
+}
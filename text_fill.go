@@ -0,0 +1,78 @@
+package main
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+// textFillSource returns the source image painted into the glyphs.
+// The fill spans the laid out text block or the whole canvas. An invalid gradient or a missing
+// texture falls back to the text color.
+func textFillSource(fill *TextFillConfig, textColor color.RGBA, block, canvas image.Rectangle) image.Image {
+	solid := image.NewUniform(textColor)
+	if fill.Gradient.Type == "" && fill.Image == "" {
+		return solid
+	}
+
+	region := block
+	switch fill.Relative {
+	case "", TextFillRelativeText:
+	case TextFillRelativeCanvas:
+		region = canvas
+	default:
+		DefaultLogger.Warning("Unknown text fill area '%s', using the text block", fill.Relative)
+	}
+	if region.Empty() {
+		return solid
+	}
+
+	if fill.Gradient.Type != "" {
+		gradient, err := createGradientBackground(&fill.Gradient, region.Dx(), region.Dy())
+		if err != nil {
+			DefaultLogger.Warning("Failed to create text fill gradient, using the text color: %v", err)
+			return solid
+		}
+		return &edgeExtendedImage{src: gradient, offset: region.Min}
+	}
+
+	texture, err := loadImage(fill.Image)
+	if err != nil {
+		DefaultLogger.Warning("Failed to load text fill image '%s', using the text color: %v", fill.Image, err)
+		return solid
+	}
+	fitted := imaging.Fill(texture, region.Dx(), region.Dy(), imaging.Center, imaging.Lanczos)
+	return &edgeExtendedImage{src: fitted, offset: region.Min}
+}
+
+// edgeExtendedImage places an image at an offset and extends it infinitely by repeating its edge
+// pixels, so that glyph parts reaching outside the fill area, such as descenders, are still painted.
+type edgeExtendedImage struct {
+	src    image.Image
+	offset image.Point
+}
+
+func (e *edgeExtendedImage) ColorModel() color.Model {
+	return e.src.ColorModel()
+}
+
+func (e *edgeExtendedImage) Bounds() image.Rectangle {
+	return image.Rectangle{Min: image.Point{X: -1e9, Y: -1e9}, Max: image.Point{X: 1e9, Y: 1e9}}
+}
+
+func (e *edgeExtendedImage) At(x, y int) color.Color {
+	bounds := e.src.Bounds()
+	return e.src.At(clampToRange(x-e.offset.X, bounds.Min.X, bounds.Max.X-1), clampToRange(y-e.offset.Y, bounds.Min.Y, bounds.Max.Y-1))
+}
+
+// clampToRange limits v to the range [lo, hi].
+func clampToRange(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+// newTestFillGradient returns an undithered horizontal gradient from black to red.
+func newTestFillGradient() GradientConfig {
+	return GradientConfig{
+		Type:  GradientLinear,
+		Angle: 90,
+		Stops: []GradientStop{{Color: "#000000"}, {Color: "#FF0000"}},
+	}
+}
+
+// TestTextFillSource_GradientRelativeToText tests that the gradient spans the text block and extends beyond it.
+func TestTextFillSource_GradientRelativeToText(t *testing.T) {
+	fill := &TextFillConfig{Gradient: newTestFillGradient(), Relative: TextFillRelativeText}
+	block := image.Rect(100, 50, 300, 100)
+	canvas := image.Rect(0, 0, DefaultImageWidth, DefaultImageHeight)
+
+	src := textFillSource(fill, color.RGBA{A: 255}, block, canvas)
+
+	start := color.RGBAModel.Convert(src.At(100, 60)).(color.RGBA)
+	end := color.RGBAModel.Convert(src.At(299, 60)).(color.RGBA)
+	if start.R > 5 || end.R < 250 {
+		t.Errorf("Expected the gradient to span the block, got %v at the start and %v at the end", start, end)
+	}
+
+	expectColor(t, src.At(20, 10), start)
+	expectColor(t, src.At(600, 120), end)
+}
+
+// TestTextFillSource_GradientRelativeToCanvas tests that the gradient spans the whole image.
+func TestTextFillSource_GradientRelativeToCanvas(t *testing.T) {
+	fill := &TextFillConfig{Gradient: newTestFillGradient(), Relative: TextFillRelativeCanvas}
+	block := image.Rect(100, 50, 300, 100)
+	canvas := image.Rect(0, 0, DefaultImageWidth, DefaultImageHeight)
+
+	src := textFillSource(fill, color.RGBA{A: 255}, block, canvas)
+
+	middle := color.RGBAModel.Convert(src.At(DefaultImageWidth/2, 60)).(color.RGBA)
+	if absDiff(middle.R, 128) > 3 {
+		t.Errorf("Expected half red in the middle of the canvas, got %v", middle)
+	}
+	if blockEnd := color.RGBAModel.Convert(src.At(299, 60)).(color.RGBA); blockEnd.R > 70 {
+		t.Errorf("Expected the block end to be dark with a canvas gradient, got %v", blockEnd)
+	}
+}
+
+// TestTextFillSource_Image tests texture fills and the fallback to the text color.
+func TestTextFillSource_Image(t *testing.T) {
+	texturePath := filepath.Join(t.TempDir(), "texture.png")
+	texture := color.RGBA{R: 10, G: 200, B: 30, A: 255}
+	writeSolidPNG(t, texturePath, texture)
+
+	textColor := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	block := image.Rect(10, 10, 110, 60)
+	canvas := image.Rect(0, 0, 200, 100)
+
+	src := textFillSource(&TextFillConfig{Image: texturePath}, textColor, block, canvas)
+	expectColor(t, src.At(50, 30), texture)
+	expectColor(t, src.At(150, 90), texture)
+
+	missing := &TextFillConfig{Image: filepath.Join(t.TempDir(), "missing.png")}
+	expectColor(t, textFillSource(missing, textColor, block, canvas).At(50, 30), textColor)
+
+	invalid := &TextFillConfig{Gradient: GradientConfig{Type: GradientLinear}}
+	expectColor(t, textFillSource(invalid, textColor, block, canvas).At(50, 30), textColor)
+}
+
+// TestRenderTextElement_GradientFill tests that the glyphs are painted with the gradient.
+func TestRenderTextElement_GradientFill(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	img := newFilledRGBA(300, 140, white)
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 48
+	textConfig.Overflow = OverflowClip
+	textConfig.Area = TextArea{X: 10, Y: 10, Width: 280, Height: 120}
+	textConfig.Fill = TextFillConfig{
+		Gradient: GradientConfig{Type: GradientLinear, Angle: 90, Stops: []GradientStop{{Color: "#FF0000"}, {Color: "#0000FF"}}},
+		Relative: TextFillRelativeText,
+	}
+
+	if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "HHHHHH", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	ink := inkBounds(img, white)
+	if ink.Empty() {
+		t.Fatal("Expected rendered text")
+	}
+
+	// Sum the red-blue balance of the ink in the left and right quarters of the text
+	balance := func(x0, x1 int) int {
+		total := 0
+		for y := ink.Min.Y; y < ink.Max.Y; y++ {
+			for x := x0; x < x1; x++ {
+				c := img.RGBAAt(x, y)
+				total += int(c.R) - int(c.B)
+			}
+		}
+		return total
+	}
+	quarter := ink.Dx() / 4
+	if left := balance(ink.Min.X, ink.Min.X+quarter); left <= 0 {
+		t.Errorf("Expected red glyphs on the left, got balance %d", left)
+	}
+	if right := balance(ink.Max.X-quarter, ink.Max.X); right >= 0 {
+		t.Errorf("Expected blue glyphs on the right, got balance %d", right)
+	}
+}
+
+// TestArticleProcessor_ResolveTextAssetPaths tests that texture paths fall back to the config directory.
+func TestArticleProcessor_ResolveTextAssetPaths(t *testing.T) {
+	configDir := t.TempDir()
+	ap := &ArticleProcessor{configDir: configDir}
+
+	config := getDefaultConfig()
+	config.Title.Fill.Image = "textures/paper.png"
+	config.Texts = map[string]TextConfig{"badge": {Fill: TextFillConfig{Image: "badge.png"}}}
+
+	ap.resolveTextAssetPaths(config, t.TempDir())
+
+	if expected := filepath.Join(configDir, "textures/paper.png"); config.Title.Fill.Image != expected {
+		t.Errorf("Expected title texture %s, got %s", expected, config.Title.Fill.Image)
+	}
+	if expected := filepath.Join(configDir, "badge.png"); config.Texts["badge"].Fill.Image != expected {
+		t.Errorf("Expected badge texture %s, got %s", expected, config.Texts["badge"].Fill.Image)
+	}
+	if config.Description.Fill.Image != "" {
+		t.Errorf("Expected no description texture, got %s", config.Description.Fill.Image)
+	}
+}
+
+// TestMergeConfigsWithSettings_TextFill tests merging text fills from global settings and front matter.
+func TestMergeConfigsWithSettings_TextFill(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{
+			Fill: &TextFillSettings{
+				Gradient: &GradientSettings{
+					Type:  stringPtr(GradientLinear),
+					Stops: []GradientStop{{Color: "#FF512F"}, {Color: "#DD2476"}},
+				},
+			},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			Fill: &TextFillSettings{
+				Gradient: &GradientSettings{Angle: float64Ptr(45)},
+				Relative: stringPtr(TextFillRelativeCanvas),
+			},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	fill := config.Title.Fill
+
+	if fill.Gradient.Type != GradientLinear || fill.Gradient.Angle != 45 || !fill.Gradient.Dither {
+		t.Errorf("Unexpected merged gradient %+v", fill.Gradient)
+	}
+	if len(fill.Gradient.Stops) != 2 || fill.Gradient.Stops[1].Color != "#DD2476" {
+		t.Errorf("Expected global stops, got %+v", fill.Gradient.Stops)
+	}
+	if fill.Relative != TextFillRelativeCanvas {
+		t.Errorf("Expected canvas relative fill, got %s", fill.Relative)
+	}
+	if config.Description.Fill.Gradient.Type != "" || config.Description.Fill.Relative != TextFillRelativeText {
+		t.Errorf("Expected default description fill, got %+v", config.Description.Fill)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// textRuns splits the text of an element into styled runs by its inline markup and highlight rules.
+// It returns nil for text that is drawn in a single style.
+func (ir *ImageRenderer) textRuns(textConfig *TextConfig, text string) []textRun {
+	if !textConfig.Markup.Enabled && len(textConfig.Highlights) == 0 {
+		return nil
+	}
+
+	runs := []textRun{{Text: text}}
+	if textConfig.Markup.Enabled {
+		runs = parseMarkup(text)
+	}
+	if len(textConfig.Highlights) > 0 {
+		runs = ir.applyHighlights(runs, textConfig.Highlights)
+	}
+	return runs
+}
+
+// applyHighlights marks the runes of the runs matched by the highlight rules.
+// Matches are found in the text without markup, so a rule can match across styled runs.
+// Where matches of several rules overlap, the earlier rule takes precedence.
+func (ir *ImageRenderer) applyHighlights(runs []textRun, rules []TextHighlightConfig) []textRun {
+	text := runsText(runs)
+
+	var runes []rune
+	var formats []textRun
+	for _, run := range runs {
+		for _, r := range run.Text {
+			runes = append(runes, r)
+			formats = append(formats, textRun{Style: run.Style})
+		}
+	}
+
+	// Regular expressions match byte offsets, which are mapped to rune indices
+	runeIndex := make([]int, len(text)+1)
+	i := 0
+	for offset := range text {
+		runeIndex[offset] = i
+		i++
+	}
+	runeIndex[len(text)] = i
+
+	for ruleIndex := range rules {
+		re := ir.highlightRegexp(&rules[ruleIndex])
+		if re == nil {
+			continue
+		}
+		for _, match := range re.FindAllStringIndex(text, -1) {
+			for r := runeIndex[match[0]]; r < runeIndex[match[1]]; r++ {
+				if formats[r].Rule == 0 {
+					formats[r].Rule = ruleIndex + 1
+				}
+			}
+		}
+	}
+
+	return groupRuns(runes, formats)
+}
+
+// highlightPattern builds the regular expression of a highlight rule from its pattern and words.
+// Words are quoted, longer words are preferred, and words starting or ending with a Latin letter
+// or digit only match at word boundaries, so that "Go" does not match inside "Google".
+func highlightPattern(rule *TextHighlightConfig) string {
+	var alternatives []string
+	if rule.Pattern != "" {
+		alternatives = append(alternatives, "(?:"+rule.Pattern+")")
+	}
+
+	words := append([]string(nil), rule.Words...)
+	sort.SliceStable(words, func(i, j int) bool {
+		return utf8.RuneCountInString(words[i]) > utf8.RuneCountInString(words[j])
+	})
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		quoted := regexp.QuoteMeta(word)
+		if first, _ := utf8.DecodeRuneInString(word); isASCIIWordChar(first) {
+			quoted = `\b` + quoted
+		}
+		if last, _ := utf8.DecodeLastRuneInString(word); isASCIIWordChar(last) {
+			quoted += `\b`
+		}
+		alternatives = append(alternatives, quoted)
+	}
+
+	if len(alternatives) == 0 {
+		return ""
+	}
+	pattern := strings.Join(alternatives, "|")
+	if rule.IgnoreCase {
+		pattern = "(?i)" + pattern
+	}
+	return pattern
+}
+
+// isASCIIWordChar reports whether r is a word character for the \b assertion of regular expressions.
+func isASCIIWordChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_'
+}
+
+// highlightRegexp returns the compiled regular expression of a highlight rule, or nil if the rule
+// is empty or invalid. Expressions are cached, so an invalid pattern is reported once.
+func (ir *ImageRenderer) highlightRegexp(rule *TextHighlightConfig) *regexp.Regexp {
+	pattern := highlightPattern(rule)
+	if pattern == "" {
+		return nil
+	}
+	if re, exists := ir.highlightPatterns[pattern]; exists {
+		return re
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		DefaultLogger.Warning("Failed to compile highlight pattern '%s', ignoring the rule: %v", rule.Pattern, err)
+		re = nil
+	} else if re.MatchString("") {
+		DefaultLogger.Warning("Highlight pattern '%s' matches empty text, ignoring the rule", rule.Pattern)
+		re = nil
+	}
+	ir.highlightPatterns[pattern] = re
+	return re
+}
+
+// highlightFonts returns the font of each highlight rule, or nil for rules that keep the text font.
+func (ir *ImageRenderer) highlightFonts(rules []TextHighlightConfig) []*truetype.Font {
+	fonts := make([]*truetype.Font, len(rules))
+	for i, rule := range rules {
+		if rule.Font != nil && *rule.Font != "" {
+			fonts[i] = ir.loadRunFont(*rule.Font)
+		}
+	}
+	return fonts
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"image/color"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestApplyHighlights tests styling matches of patterns and words across markup runs.
+func TestApplyHighlights(t *testing.T) {
+	tests := []struct {
+		name     string
+		runs     []textRun
+		rules    []TextHighlightConfig
+		expected []textRun
+	}{
+		{
+			name:  "version pattern",
+			runs:  []textRun{{Text: "Go v1.22 released"}},
+			rules: []TextHighlightConfig{{Pattern: `v\d+\.\d+`}},
+			expected: []textRun{
+				{Text: "Go "},
+				{Text: "v1.22", Rule: 1},
+				{Text: " released"},
+			},
+		},
+		{
+			name:  "words at word boundaries",
+			runs:  []textRun{{Text: "Go and Google"}},
+			rules: []TextHighlightConfig{{Words: []string{"Go"}}},
+			expected: []textRun{
+				{Text: "Go", Rule: 1},
+				{Text: " and Google"},
+			},
+		},
+		{
+			name:  "japanese words",
+			runs:  []textRun{{Text: "新機能の紹介"}},
+			rules: []TextHighlightConfig{{Words: []string{"新機能"}}},
+			expected: []textRun{
+				{Text: "新機能", Rule: 1},
+				{Text: "の紹介"},
+			},
+		},
+		{
+			name:  "longer words first and ignore case",
+			runs:  []textRun{{Text: "hugo modules"}},
+			rules: []TextHighlightConfig{{Words: []string{"Hugo", "Hugo Modules"}, IgnoreCase: true}},
+			expected: []textRun{
+				{Text: "hugo modules", Rule: 1},
+			},
+		},
+		{
+			name: "earlier rules take precedence",
+			runs: []textRun{{Text: "Release 2.0"}},
+			rules: []TextHighlightConfig{
+				{Pattern: `\d`},
+				{Pattern: `Release \d\.\d`},
+			},
+			expected: []textRun{
+				{Text: "Release ", Rule: 2},
+				{Text: "2", Rule: 1},
+				{Text: ".", Rule: 2},
+				{Text: "0", Rule: 1},
+			},
+		},
+		{
+			name:  "matches across markup runs",
+			runs:  []textRun{{Text: "New "}, {Text: "Go", Style: markupBold}, {Text: " 1.22"}},
+			rules: []TextHighlightConfig{{Pattern: `Go \d+\.\d+`}},
+			expected: []textRun{
+				{Text: "New "},
+				{Text: "Go", Style: markupBold, Rule: 1},
+				{Text: " 1.22", Rule: 1},
+			},
+		},
+		{
+			name:     "invalid and empty patterns are ignored",
+			runs:     []textRun{{Text: "text"}},
+			rules:    []TextHighlightConfig{{Pattern: `(`}, {Pattern: `x*`}},
+			expected: []textRun{{Text: "text"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewImageRenderer().applyHighlights(tt.runs, tt.rules); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("applyHighlights() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRunStyle_HighlightPrecedence tests that highlight rules override markup colors.
+func TestRunStyle_HighlightPrecedence(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	textConfig.Markup.Bold = TextRunStyleConfig{Color: "#FF0000", Background: "#EEEEEE"}
+	textConfig.Highlights = []TextHighlightConfig{{Pattern: "x", Color: "#0000FF"}}
+
+	got := runStyle(textConfig, textRun{Style: markupBold, Rule: 1})
+	if expected := (TextRunStyleConfig{Color: "#0000FF", Background: "#EEEEEE"}); got != expected {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+// TestRenderTextElement_Highlights tests that matches are drawn with the rule color without markup.
+func TestRenderTextElement_Highlights(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Color = "#000000"
+	textConfig.Size = 40
+	textConfig.Overflow = OverflowClip
+	textConfig.BlockPosition = "top-left"
+	textConfig.Area = TextArea{X: 20, Y: 20, Width: 460, Height: 100}
+	textConfig.Highlights = []TextHighlightConfig{{Words: []string{"Hugo"}, Color: "#0000FF"}}
+
+	img := newFilledRGBA(500, 140, white)
+	if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "Hello Hugo", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	// Blue ink is only found in the right part of the line
+	ink := inkBounds(img, white)
+	firstBlue := -1
+	for x := ink.Min.X; x < ink.Max.X && firstBlue < 0; x++ {
+		for y := ink.Min.Y; y < ink.Max.Y; y++ {
+			if img.RGBAAt(x, y) == blue {
+				firstBlue = x
+				break
+			}
+		}
+	}
+	if firstBlue < 0 || firstBlue < ink.Min.X+ink.Dx()/3 {
+		t.Errorf("Expected blue glyphs for the matched word only, first blue at x=%d within %v", firstBlue, ink)
+	}
+}
+
+// TestArticleProcessor_ResolveHighlightFonts tests that highlight font paths fall back to the config directory.
+func TestArticleProcessor_ResolveHighlightFonts(t *testing.T) {
+	configDir := t.TempDir()
+	ap := &ArticleProcessor{configDir: configDir}
+
+	config := getDefaultConfig()
+	config.Title.Highlights = []TextHighlightConfig{{Words: []string{"Go"}, Font: stringPtr("fonts/brand.ttf")}, {Pattern: "v1"}}
+
+	ap.resolveTextAssetPaths(config, t.TempDir())
+
+	if expected := filepath.Join(configDir, "fonts/brand.ttf"); *config.Title.Highlights[0].Font != expected {
+		t.Errorf("Expected highlight font %s, got %s", expected, *config.Title.Highlights[0].Font)
+	}
+	if config.Title.Highlights[1].Font != nil {
+		t.Errorf("Expected no font for the second rule, got %s", *config.Title.Highlights[1].Font)
+	}
+}
+
+// TestMergeConfigsWithSettings_Highlights tests that type and front matter rules replace inherited rules.
+func TestMergeConfigsWithSettings_Highlights(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{Highlights: []TextHighlightConfig{{Words: []string{"Hugo"}, Color: "#FF4088"}}},
+	}
+	typeSettings := &ConfigSettings{
+		Title: &TextSettings{Highlights: []TextHighlightConfig{{Pattern: `v\d+`, Background: "#FFE066"}}},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, typeSettings, nil)
+	if len(config.Title.Highlights) != 1 || config.Title.Highlights[0].Pattern != `v\d+` {
+		t.Errorf("Expected the type rules, got %+v", config.Title.Highlights)
+	}
+	if len(config.Description.Highlights) != 0 {
+		t.Errorf("Expected no description rules, got %+v", config.Description.Highlights)
+	}
+
+	fm := &OGPFrontMatter{Title: &TextConfigOverride{Highlights: []TextHighlightConfig{}}}
+	config = NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, typeSettings, fm)
+	if len(config.Title.Highlights) != 0 {
+		t.Errorf("Expected an empty front matter list to remove the rules, got %+v", config.Title.Highlights)
+	}
+
+	// The merged rules do not share the words of the settings
+	config = NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, nil)
+	config.Title.Highlights[0].Words[0] = "Changed"
+	if global.Title.Highlights[0].Words[0] != "Hugo" {
+		t.Error("Expected merged rules to be copied from the settings")
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"image"
+	"strings"
+	"unicode"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// TextLayout holds the lines of a text element laid out at a specific font size.
+type TextLayout struct {
+	FontSize      float64       // Font size used for the layout
+	Face          font.Face     // Face matching FontSize and the text style
+	Lines         []string      // Wrapped lines
+	Runs          [][]layoutRun // Styled runs of each line (nil for text without markup)
+	LineWidths    []int         // Width of each line in pixels, without trailing spaces
+	LineHeight    int           // Distance between baselines in pixels
+	LetterSpacing int           // Letter spacing in pixels
+	Width         int           // Width of the widest line
+	Height        int           // Total height of the text block
+}
+
+// layoutRun is a styled run of a laid out line with the face it is drawn with.
+type layoutRun struct {
+	textRun
+	Face font.Face
+}
+
+// layoutText wraps text at the given font size and measures the resulting lines.
+func layoutText(f *truetype.Font, text string, fontSize float64, textConfig *TextConfig, maxWidth int, textProcessor *TextProcessor) *TextLayout {
+	face := newTextConfigFace(f, fontSize, textConfig)
+	lines := textProcessor.SplitText(text, face, maxWidth)
+	return measureTextLayout(face, fontSize, lines, textConfig)
+}
+
+// layoutRuns wraps styled runs with the faces of their styles and measures the resulting lines.
+func layoutRuns(faces *runFaces, runs []textRun, textConfig *TextConfig, maxWidth int, textProcessor *TextProcessor) *TextLayout {
+	lines := textProcessor.SplitRuns(runs, faces.face, maxWidth)
+
+	layout := &TextLayout{
+		FontSize:   faces.size,
+		Face:       faces.face(textRun{}),
+		Lines:      make([]string, len(lines)),
+		Runs:       make([][]layoutRun, len(lines)),
+		LineWidths: make([]int, len(lines)),
+		LineHeight: int(faces.size * textConfig.LineHeight),
+
+		LetterSpacing: textConfig.LetterSpacing,
+	}
+
+	for i, line := range lines {
+		layout.Lines[i] = runsText(line)
+		layout.Runs[i] = make([]layoutRun, len(line))
+		for j, run := range line {
+			layout.Runs[i][j] = layoutRun{textRun: run, Face: faces.face(run)}
+		}
+		layout.LineWidths[i] = runsWidth(trimTrailingSpaces(layout.Runs[i]), textConfig.LetterSpacing).Ceil()
+		if layout.LineWidths[i] > layout.Width {
+			layout.Width = layout.LineWidths[i]
+		}
+	}
+	layout.Height = len(lines) * layout.LineHeight
+
+	return layout
+}
+
+// runExtents returns the start and end of each run of a line that starts at x.
+// Letter spacing separates the runs, but kerning does not apply across them.
+func runExtents(runs []layoutRun, x fixed.Int26_6, letterSpacingPx int) [][2]fixed.Int26_6 {
+	extents := make([][2]fixed.Int26_6, len(runs))
+	for i, run := range runs {
+		if i > 0 {
+			x += fixed.I(letterSpacingPx)
+		}
+		extents[i][0] = x
+		x += measureStringWithSpacingFixed(run.Face, run.Text, letterSpacingPx)
+		extents[i][1] = x
+	}
+	return extents
+}
+
+// runsWidth returns the width of a line of runs.
+func runsWidth(runs []layoutRun, letterSpacingPx int) fixed.Int26_6 {
+	if len(runs) == 0 {
+		return 0
+	}
+	extents := runExtents(runs, 0, letterSpacingPx)
+	return extents[len(extents)-1][1]
+}
+
+// trimTrailingSpaces returns the runs of a line without the spaces at its end.
+func trimTrailingSpaces(runs []layoutRun) []layoutRun {
+	runs = append([]layoutRun(nil), runs...)
+	for len(runs) > 0 {
+		last := &runs[len(runs)-1]
+		last.Text = strings.TrimRightFunc(last.Text, unicode.IsSpace)
+		if last.Text != "" {
+			break
+		}
+		runs = runs[:len(runs)-1]
+	}
+	return runs
+}
+
+// measureTextLayout measures already wrapped lines with the given face.
+func measureTextLayout(face font.Face, fontSize float64, lines []string, textConfig *TextConfig) *TextLayout {
+	layout := &TextLayout{
+		FontSize:   fontSize,
+		Face:       face,
+		Lines:      lines,
+		LineWidths: make([]int, len(lines)),
+		LineHeight: int(fontSize * textConfig.LineHeight),
+
+		LetterSpacing: textConfig.LetterSpacing,
+	}
+
+	for i, line := range lines {
+		layout.LineWidths[i] = measureStringWithSpacing(face, strings.TrimRightFunc(line, unicode.IsSpace), textConfig.LetterSpacing)
+		if layout.LineWidths[i] > layout.Width {
+			layout.Width = layout.LineWidths[i]
+		}
+	}
+	layout.Height = len(lines) * layout.LineHeight
+
+	return layout
+}
+
+// fitsIn reports whether the laid out text block fits within the area.
+func (l *TextLayout) fitsIn(area TextArea) bool {
+	return l.Width <= area.Width && l.Height <= area.Height
+}
+
+// lineOrigins returns the start of the baseline of each line when the block is positioned
+// within the area by alignment and each line is aligned within the block by lineAlignment.
+func (l *TextLayout) lineOrigins(area TextArea, alignment, lineAlignment string) []image.Point {
+	blockX, blockY := calculateTextPosition(area, alignment, l.Width, l.Height)
+
+	origins := make([]image.Point, len(l.Lines))
+	for i := range l.Lines {
+		textWidthPx := l.LineWidths[i]
+
+		var lineX int
+		switch lineAlignment {
+		case "left":
+			lineX = blockX
+		case "right":
+			lineX = blockX + l.Width - textWidthPx
+		default:
+			lineX = blockX + (l.Width-textWidthPx)/2
+		}
+
+		origins[i] = image.Point{X: lineX, Y: blockY + l.LineHeight + i*l.LineHeight}
+	}
+	return origins
+}
+
+// cachedFace wraps a font face and memoizes glyph advances and kerning pairs.
+// Line fitting measures the same glyphs many times, so caching avoids repeated
+// glyph lookups in the font tables. Like the faces it wraps, it is not safe for concurrent use.
+type cachedFace struct {
+	font.Face
+	advances map[rune]cachedAdvance
+	kerns    map[[2]rune]fixed.Int26_6
+}
+
+// cachedAdvance is a memoized GlyphAdvance result.
+type cachedAdvance struct {
+	advance fixed.Int26_6
+	ok      bool
+}
+
+// newCachedFace wraps face with a glyph advance cache.
+func newCachedFace(face font.Face) *cachedFace {
+	return &cachedFace{
+		Face:     face,
+		advances: make(map[rune]cachedAdvance),
+		kerns:    make(map[[2]rune]fixed.Int26_6),
+	}
+}
+
+// GlyphAdvance implements font.Face.
+func (cf *cachedFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	if cached, exists := cf.advances[r]; exists {
+		return cached.advance, cached.ok
+	}
+	advance, ok := cf.Face.GlyphAdvance(r)
+	cf.advances[r] = cachedAdvance{advance: advance, ok: ok}
+	return advance, ok
+}
+
+// Kern implements font.Face.
+func (cf *cachedFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	key := [2]rune{r0, r1}
+	if kern, exists := cf.kerns[key]; exists {
+		return kern
+	}
+	kern := cf.Face.Kern(r0, r1)
+	cf.kerns[key] = kern
+	return kern
+}
+
+// runeMeasurer measures spans of the runes being wrapped. Runes may be drawn with different
+// faces; kerning only applies between neighbouring runes of the same face.
+type runeMeasurer struct {
+	runes         []rune
+	faces         []font.Face // Face of each rune (nil when all runes use face)
+	face          font.Face
+	letterSpacing int
+}
+
+// faceAt returns the face of rune i.
+func (m *runeMeasurer) faceAt(i int) font.Face {
+	if m.faces != nil {
+		return m.faces[i]
+	}
+	return m.face
+}
+
+// appendWidth returns the width of the span from start after appending rune i, given the
+// width of the span up to i. It lets line fitting accumulate widths incrementally instead
+// of re-measuring the whole candidate line.
+func (m *runeMeasurer) appendWidth(width fixed.Int26_6, start, i int) fixed.Int26_6 {
+	face := m.faceAt(i)
+	if i > start {
+		width += fixed.I(m.letterSpacing)
+		if m.faceAt(i-1) == face {
+			width += face.Kern(m.runes[i-1], m.runes[i])
+		}
+	}
+	advance, _ := face.GlyphAdvance(m.runes[i])
+	return width + advance
+}
+
+// width returns the width of the runes from start to end.
+func (m *runeMeasurer) width(start, end int) fixed.Int26_6 {
+	var width fixed.Int26_6
+	for i := start; i < end; i++ {
+		width = m.appendWidth(width, start, i)
+	}
+	return width
+}
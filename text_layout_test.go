@@ -0,0 +1,189 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func newTestLayoutConfig() *TextConfig {
+	config := getDefaultConfig()
+	textConfig := config.Title
+	return &textConfig
+}
+
+func TestCachedFace_MemoizesAdvances(t *testing.T) {
+	base := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 32})
+	cached := newCachedFace(base)
+
+	expected, _ := base.GlyphAdvance('W')
+	for i := 0; i < 3; i++ {
+		advance, ok := cached.GlyphAdvance('W')
+		if !ok || advance != expected {
+			t.Errorf("Cached advance = %v, %v; want %v, true", advance, ok, expected)
+		}
+	}
+	cached.Kern('A', 'V')
+
+	if len(cached.advances) != 1 {
+		t.Errorf("Expected 1 cached advance, got %d", len(cached.advances))
+	}
+	if len(cached.kerns) != 1 {
+		t.Errorf("Expected 1 cached kerning pair, got %d", len(cached.kerns))
+	}
+}
+
+func TestTextProcessor_SplitText_IncrementalWidthFits(t *testing.T) {
+	face := newTextConfigFace(parseTestFont(t), 28, newTestLayoutConfig())
+	startProhibited, endProhibited := buildProhibitedMaps(newTestLayoutConfig())
+
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 8)
+	for _, letterSpacing := range []int{0, 3} {
+		tp := NewTextProcessor(startProhibited, endProhibited, letterSpacing)
+		lines := tp.SplitText(text, face, 400)
+
+		if len(lines) < 2 {
+			t.Fatalf("Expected text to wrap, got %d lines", len(lines))
+		}
+		if strings.Join(lines, "") != text {
+			t.Error("Wrapped lines should contain all input characters")
+		}
+		for i, line := range lines {
+			// Spaces at the end of a line are not drawn, so they may extend past the width
+			if width := measureStringWithSpacing(face, strings.TrimRight(line, " "), letterSpacing); width > 400 {
+				t.Errorf("Line %d (%q) is %dpx wide, exceeds 400px", i, line, width)
+			}
+		}
+	}
+}
+
+func TestImageRenderer_adjustFontSizeToFit_FindsLargestFittingSize(t *testing.T) {
+	font := parseTestFont(t)
+	renderer := NewImageRenderer()
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 120
+	textConfig.MinSize = 10
+
+	area := TextArea{X: 0, Y: 0, Width: 500, Height: 160}
+	text := "A reasonably long title that needs to wrap onto several lines"
+
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+	tp := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
+
+	layout := renderer.adjustFontSizeToFit(font, text, textConfig, area, tp)
+
+	if !layout.fitsIn(area) {
+		t.Fatalf("Layout at %.2f should fit the area: %dx%d", layout.FontSize, layout.Width, layout.Height)
+	}
+	if layout.FontSize >= textConfig.Size || layout.FontSize < textConfig.MinSize {
+		t.Errorf("Font size %.2f should be between min and configured size", layout.FontSize)
+	}
+
+	// A noticeably larger size should no longer fit
+	larger := layoutText(font, text, layout.FontSize+2*FontSizeSearchPrecision, textConfig, area.Width, tp)
+	if larger.fitsIn(area) {
+		t.Errorf("Expected %.2f to be close to the largest fitting size", layout.FontSize)
+	}
+}
+
+func TestImageRenderer_adjustFontSizeToFit_KeepsSizeWhenFitting(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+	tp := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
+
+	layout := NewImageRenderer().adjustFontSizeToFit(parseTestFont(t), "Hi", textConfig, TextArea{Width: 1000, Height: 250}, tp)
+	if layout.FontSize != textConfig.Size {
+		t.Errorf("Expected configured size %.1f, got %.1f", textConfig.Size, layout.FontSize)
+	}
+}
+
+func TestImageRenderer_adjustFontSizeToFit_GrowsInFitMode(t *testing.T) {
+	font := parseTestFont(t)
+	renderer := NewImageRenderer()
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Overflow = OverflowFit
+	textConfig.Size = 64
+	textConfig.MaxSize = 400
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+	tp := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
+	area := TextArea{Width: 1000, Height: 250}
+
+	// Short text grows to the largest size that fits below the maximum
+	layout := renderer.adjustFontSizeToFit(font, "Hello", textConfig, area, tp)
+	if !layout.fitsIn(area) || layout.FontSize <= textConfig.Size || layout.FontSize >= textConfig.MaxSize {
+		t.Fatalf("Expected a grown size that fits, got %.2f (%dx%d)", layout.FontSize, layout.Width, layout.Height)
+	}
+	larger := layoutText(font, "Hello", layout.FontSize+2*FontSizeSearchPrecision, textConfig, area.Width, tp)
+	if larger.fitsIn(area) {
+		t.Errorf("Expected %.2f to be close to the largest fitting size", layout.FontSize)
+	}
+
+	// Long text still shrinks below the configured size
+	long := strings.Repeat("A long title that wraps ", 8)
+	if layout := renderer.adjustFontSizeToFit(font, long, textConfig, area, tp); layout.FontSize >= textConfig.Size {
+		t.Errorf("Expected long text to shrink, got %.2f", layout.FontSize)
+	}
+
+	// Other modes never grow the text
+	textConfig.Overflow = OverflowShrink
+	if layout := renderer.adjustFontSizeToFit(font, "Hello", textConfig, area, tp); layout.FontSize != textConfig.Size {
+		t.Errorf("Expected configured size %.1f in shrink mode, got %.1f", textConfig.Size, layout.FontSize)
+	}
+}
+
+func TestMergeConfigsWithSettings_MaxSize(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{Overflow: stringPtr(OverflowFit), MaxSize: float64Ptr(96)},
+	}
+	fm := &OGPFrontMatter{
+		Description: &TextConfigOverride{MaxSize: float64Ptr(40)},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	if config.Title.Overflow != OverflowFit || config.Title.MaxSize != 96 {
+		t.Errorf("Unexpected merged title %s %.1f", config.Title.Overflow, config.Title.MaxSize)
+	}
+	if config.Description.MaxSize != 40 {
+		t.Errorf("Expected description max size 40, got %.1f", config.Description.MaxSize)
+	}
+}
+
+func TestMeasureTextLayout_UsesLayoutFontSize(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	textConfig.LineHeight = 1.5
+
+	face := newTextConfigFace(parseTestFont(t), 20, textConfig)
+	layout := measureTextLayout(face, 20, []string{"one", "three"}, textConfig)
+
+	if layout.LineHeight != 30 {
+		t.Errorf("Expected line height 30 for size 20, got %d", layout.LineHeight)
+	}
+	if layout.Height != 60 {
+		t.Errorf("Expected block height 60, got %d", layout.Height)
+	}
+	if layout.Width != layout.LineWidths[1] || layout.LineWidths[1] <= layout.LineWidths[0] {
+		t.Errorf("Expected widest line to define block width: %v", layout.LineWidths)
+	}
+}
+
+func BenchmarkTextProcessor_SplitText(b *testing.B) {
+	font, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		b.Fatalf("Failed to parse font: %v", err)
+	}
+	textConfig := newTestLayoutConfig()
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+	tp := NewTextProcessor(startProhibited, endProhibited, 1)
+	text := strings.Repeat("長い説明文のテストです。Long description text for benchmarking. ", 20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		face := newTextConfigFace(font, 32, textConfig)
+		tp.SplitText(text, face, 1000)
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"unicode"
+)
+
+// lineBreakClass is a line breaking class of the Unicode line breaking algorithm (UAX #14).
+type lineBreakClass uint8
+
+const (
+	lbAL  lineBreakClass = iota // Alphabetic (also unknown, ambiguous and complex context characters)
+	lbB2                        // Break opportunity before and after, such as an em dash
+	lbBA                        // Break after, such as tabs and breaking spaces other than the space
+	lbBB                        // Break before
+	lbBK                        // Mandatory break
+	lbCL                        // Close punctuation
+	lbCM                        // Combining mark
+	lbCP                        // Close parenthesis
+	lbCR                        // Carriage return
+	lbEB                        // Emoji base
+	lbEM                        // Emoji modifier
+	lbEX                        // Exclamation or interrogation
+	lbGL                        // Non-breaking glue
+	lbH2                        // Hangul LV syllable
+	lbH3                        // Hangul LVT syllable
+	lbHL                        // Hebrew letter
+	lbHY                        // Hyphen-minus
+	lbID                        // Ideographic
+	lbIN                        // Inseparable, such as an ellipsis
+	lbIS                        // Infix numeric separator
+	lbJL                        // Hangul leading jamo
+	lbJT                        // Hangul trailing jamo
+	lbJV                        // Hangul vowel jamo
+	lbLF                        // Line feed
+	lbNL                        // Next line
+	lbNS                        // Nonstarter
+	lbNU                        // Numeric
+	lbOP                        // Open punctuation
+	lbPO                        // Postfix numeric
+	lbPR                        // Prefix numeric
+	lbQU                        // Quotation
+	lbRI                        // Regional indicator
+	lbSP                        // Space
+	lbSY                        // Symbol allowing a break after, such as a slash
+	lbWJ                        // Word joiner
+	lbZW                        // Zero width space
+	lbZWJ                       // Zero width joiner
+)
+
+// lineBreakClasses holds the classes of characters that their general category does not determine.
+var lineBreakClasses = map[rune]lineBreakClass{
+	'\t': lbBA, '\n': lbLF, '\v': lbBK, '\f': lbBK, '\r': lbCR, ' ': lbSP,
+	'!': lbEX, '"': lbQU, '$': lbPR, '%': lbPO, '\'': lbQU, '(': lbOP, ')': lbCP,
+	'+': lbPR, ',': lbIS, '-': lbHY, '.': lbIS, '/': lbSY, ':': lbIS, ';': lbIS,
+	'?': lbEX, '[': lbOP, '\\': lbPR, ']': lbCP, '{': lbOP, '|': lbBA, '}': lbCL,
+	0x85: lbNL, 0xA0: lbGL, 0xA2: lbPO, 0xA3: lbPR, 0xA4: lbPR, 0xA5: lbPR,
+	0xAD: lbBA, 0xB0: lbPO, 0xB1: lbPR, 0xB4: lbBB, 0x2007: lbGL,
+	0x200B: lbZW, 0x200D: lbZWJ, 0x2010: lbBA, 0x2011: lbGL, 0x2012: lbBA, 0x2013: lbBA,
+	0x2014: lbB2, 0x2024: lbIN, 0x2025: lbIN, 0x2026: lbIN, 0x2028: lbBK, 0x2029: lbBK,
+	0x202F: lbGL, 0x2030: lbPO, 0x2031: lbPO, 0x2032: lbPO, 0x2033: lbPO, 0x2034: lbPO,
+	0x203C: lbNS, 0x203D: lbNS, 0x2044: lbIS, 0x2047: lbNS, 0x2048: lbNS, 0x2049: lbNS,
+	0x2060: lbWJ, 0x20AC: lbPR, 0x2116: lbPR, 0x2212: lbPR, 0xFEFF: lbWJ,
+	0x3000: lbBA, 0x3001: lbCL, 0x3002: lbCL, 0x3005: lbNS, 0x301C: lbNS,
+	0x303B: lbNS, 0x309B: lbNS, 0x309C: lbNS, 0x309D: lbNS, 0x309E: lbNS,
+	0x30A0: lbNS, 0x30FB: lbNS, 0x30FC: lbNS, 0x30FD: lbNS, 0x30FE: lbNS,
+	0xFF01: lbEX, 0xFF04: lbPR, 0xFF05: lbPO, 0xFF0C: lbCL, 0xFF0E: lbCL,
+	0xFF1A: lbNS, 0xFF1B: lbNS, 0xFF1F: lbEX, 0xFF61: lbCL, 0xFF64: lbCL,
+	0xFFE0: lbPO, 0xFFE1: lbPR, 0xFFE5: lbPR, 0xFFE6: lbPR,
+}
+
+// classifyLineBreak returns the line breaking class of r. The classes of the Unicode data are
+// approximated from general categories and scripts, with explicit classes for punctuation.
+// Unknown, ambiguous and complex context characters resolve to alphabetic, and small kana
+// resolve to ideographic, as in the rules for CSS "line-break: normal"; the kinsoku
+// maps decide whether they may start a line.
+func classifyLineBreak(r rune) lineBreakClass {
+	if class, exists := lineBreakClasses[r]; exists {
+		return class
+	}
+
+	switch {
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return lbRI
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		return lbEM
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return lbH2
+		}
+		return lbH3
+	case (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C):
+		return lbJL
+	case (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6):
+		return lbJV
+	case (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB):
+		return lbJT
+	case isEmojiBase(r):
+		return lbEB
+	case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+		return lbCM
+	case unicode.Is(unicode.Cc, r):
+		return lbCM
+	case unicode.Is(unicode.Nd, r):
+		return lbNU
+	case unicode.Is(unicode.Ps, r):
+		return lbOP
+	case unicode.Is(unicode.Pe, r):
+		if isWideRune(r) {
+			return lbCL
+		}
+		return lbCP
+	case unicode.In(r, unicode.Pi, unicode.Pf):
+		return lbQU
+	case unicode.Is(unicode.Zs, r):
+		return lbBA
+	case unicode.Is(unicode.Hebrew, r) && unicode.IsLetter(r):
+		return lbHL
+	case isWideRune(r) || isPictograph(r):
+		return lbID
+	}
+	return lbAL
+}
+
+// isWideRune reports whether r belongs to the CJK scripts, symbols and fullwidth forms
+// that UAX #14 classifies as ideographic.
+func isWideRune(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Yi) ||
+		(r >= 0x2E80 && r <= 0x303F) || // CJK radicals, symbols and punctuation
+		(r >= 0x3130 && r <= 0x318F) || // Hangul compatibility jamo
+		(r >= 0x31C0 && r <= 0x33FF) || // CJK strokes, enclosed letters and compatibility
+		(r >= 0xFE30 && r <= 0xFE4F) || // CJK compatibility forms
+		(r >= 0xFF00 && r <= 0xFF60) || // Fullwidth forms
+		(r >= 0xFFE0 && r <= 0xFFE6)
+}
+
+// isPictograph reports whether r is an emoji or pictographic symbol.
+func isPictograph(r rune) bool {
+	return (r >= 0x2600 && r <= 0x27BF) || (r >= 0x1F000 && r <= 0x1FAFF)
+}
+
+// isEmojiBase reports whether r is an emoji that takes skin tone modifiers.
+func isEmojiBase(r rune) bool {
+	return r == 0x261D || r == 0x26F9 || (r >= 0x270A && r <= 0x270D) ||
+		r == 0x1F385 || (r >= 0x1F3C2 && r <= 0x1F3CC) ||
+		(r >= 0x1F442 && r <= 0x1F4AA) || (r >= 0x1F574 && r <= 0x1F596) ||
+		(r >= 0x1F645 && r <= 0x1F64F) || (r >= 0x1F6A3 && r <= 0x1F6CC) ||
+		(r >= 0x1F90C && r <= 0x1F9DD) || (r >= 0x1FAC3 && r <= 0x1FAF8)
+}
+
+// lineBreakOpportunities returns whether a line may break before each rune, following the
+// Unicode line breaking algorithm (UAX #14) tailored by the kinsoku maps: a line never starts
+// with a start prohibited character or ends with an end prohibited one. The first element,
+// before the start of the text, is always false.
+func (t *TextProcessor) lineBreakOpportunities(runes []rune) []bool {
+	breaks := make([]bool, len(runes))
+	if len(runes) == 0 {
+		return breaks
+	}
+
+	// prev is the class of the character before the position, with combining marks taking the
+	// class of their base (LB9), and beforeSpaces the class before a sequence of spaces
+	raw := classifyLineBreak(runes[0])
+	prev := raw
+	if prev == lbCM || prev == lbZWJ {
+		prev = lbAL
+	}
+	prevRune, beforeSpaces, beforePrev := runes[0], prev, lbAL
+	regionalIndicators := 0
+	if prev == lbRI {
+		regionalIndicators = 1
+	}
+
+	for i := 1; i < len(runes); i++ {
+		current := classifyLineBreak(runes[i])
+		if current == lbCM || current == lbZWJ {
+			if !isLineBreakBoundary(prev) {
+				// LB9: combining marks stay with their base and take its class
+				raw = current
+				continue
+			}
+			current = lbAL // LB10
+		}
+
+		breaks[i] = lineBreakAllowed(prev, beforeSpaces, beforePrev, current, raw, prevRune, runes[i], regionalIndicators)
+		if t.startProhibited[runes[i]] || t.endProhibited[prevRune] {
+			breaks[i] = false
+		}
+
+		if current == lbSP && prev != lbSP {
+			beforeSpaces = prev
+		}
+		if current == lbRI {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		beforePrev, prev, prevRune, raw = prev, current, runes[i], classifyLineBreak(runes[i])
+	}
+
+	return breaks
+}
+
+// isLineBreakBoundary reports whether combining marks after a character of the class do not
+// join it (LB9).
+func isLineBreakBoundary(class lineBreakClass) bool {
+	switch class {
+	case lbBK, lbCR, lbLF, lbNL, lbSP, lbZW:
+		return true
+	}
+	return false
+}
+
+// lineBreakAllowed applies the pair rules LB4 to LB31 of UAX #14 to the position between
+// a character of class prev and one of class current. raw is the class of the character
+// before the position without LB9 applied, beforeSpaces the class before the spaces when prev
+// is a space, and beforePrev the class of the character before prev.
+func lineBreakAllowed(prev, beforeSpaces, beforePrev, current, raw lineBreakClass, prevRune, currentRune rune, regionalIndicators int) bool {
+	// The class before any spaces, for the rules that look through them
+	before := prev
+	if prev == lbSP {
+		before = beforeSpaces
+	}
+
+	switch {
+	case prev == lbBK || prev == lbLF || prev == lbNL: // LB4, LB5
+		return true
+	case prev == lbCR:
+		return current != lbLF
+	case current == lbBK || current == lbCR || current == lbLF || current == lbNL: // LB6
+		return false
+	case current == lbSP || current == lbZW: // LB7
+		return false
+	case before == lbZW: // LB8
+		return true
+	case raw == lbZWJ: // LB8a
+		return false
+	case prev == lbWJ || current == lbWJ: // LB11
+		return false
+	case prev == lbGL: // LB12
+		return false
+	case current == lbGL && prev != lbSP && prev != lbBA && prev != lbHY: // LB12a
+		return false
+	case current == lbCL || current == lbCP || current == lbEX || current == lbIS || current == lbSY: // LB13
+		return false
+	case before == lbOP: // LB14
+		return false
+	case before == lbQU && current == lbOP: // LB15
+		return false
+	case (before == lbCL || before == lbCP) && current == lbNS: // LB16
+		return false
+	case before == lbB2 && current == lbB2: // LB17
+		return false
+	case prev == lbSP: // LB18
+		return true
+	case prev == lbQU || current == lbQU: // LB19
+		return false
+	case current == lbBA || current == lbHY || current == lbNS || prev == lbBB: // LB21
+		return false
+	case beforePrev == lbHL && (prev == lbHY || prev == lbBA): // LB21a
+		return false
+	case prev == lbSY && current == lbHL: // LB21b
+		return false
+	case current == lbIN: // LB22
+		return false
+	case isLetterClass(prev) && current == lbNU, prev == lbNU && isLetterClass(current): // LB23
+		return false
+	case prev == lbPR && (current == lbID || current == lbEB || current == lbEM),
+		(prev == lbID || prev == lbEB || prev == lbEM) && current == lbPO: // LB23a
+		return false
+	case (prev == lbPR || prev == lbPO) && isLetterClass(current),
+		isLetterClass(prev) && (current == lbPR || current == lbPO): // LB24
+		return false
+	case (prev == lbCL || prev == lbCP || prev == lbNU) && (current == lbPO || current == lbPR),
+		(prev == lbPO || prev == lbPR) && (current == lbOP || current == lbNU),
+		(prev == lbHY || prev == lbIS || prev == lbNU || prev == lbSY) && current == lbNU: // LB25
+		return false
+	case isHangulClass(prev) && isHangulClass(current):
+		// LB26 keeps the jamo of a syllable together; as tailored for Korean, which separates
+		// words with spaces, lines do not break between syllables either
+		return false
+	case isHangulClass(prev) && current == lbPO, prev == lbPR && isHangulClass(current): // LB27
+		return false
+	case isLetterClass(prev) && isLetterClass(current): // LB28
+		return false
+	case prev == lbIS && isLetterClass(current): // LB29
+		return false
+	case (isLetterClass(prev) || prev == lbNU) && current == lbOP && !isWideRune(currentRune),
+		prev == lbCP && (isLetterClass(current) || current == lbNU) && !isWideRune(prevRune): // LB30
+		return false
+	case prev == lbRI && current == lbRI: // LB30a
+		return regionalIndicators%2 == 0
+	case prev == lbEB && current == lbEM: // LB30b
+		return false
+	}
+	return true // LB31
+}
+
+// isLetterClass reports whether the class is alphabetic or a Hebrew letter.
+func isLetterClass(class lineBreakClass) bool {
+	return class == lbAL || class == lbHL
+}
+
+// isHangulClass reports whether the class is a Hangul syllable or jamo.
+func isHangulClass(class lineBreakClass) bool {
+	switch class {
+	case lbJL, lbJV, lbJT, lbH2, lbH3:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// TestTextProcessor_LineBreakOpportunities tests break opportunities across scripts and punctuation.
+func TestTextProcessor_LineBreakOpportunities(t *testing.T) {
+	startProhibited, endProhibited := buildProhibitedMaps(newTestLayoutConfig())
+	tp := NewTextProcessor(startProhibited, endProhibited, 0)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []int // Rune indices a line may start at
+	}{
+		{"spaces", "Hello world", []int{6}},
+		{"accented letters", "Café naïve", []int{5}},
+		{"combining marks", "Cafe\u0301 nai\u0308ve", []int{6}},
+		{"cyrillic", "Привет мир", []int{7}},
+		{"korean words", "한국어 문장", []int{4}},
+		{"url", "https://example.com/path", []int{8, 20}},
+		{"hyphen", "well-known", []int{5}},
+		{"numbers", "$100 -5", []int{5}},
+		{"ideographs", "日本語です。", []int{1, 2, 3, 4}},
+		{"brackets", "「日本」語", []int{2, 4}},
+		{"kinsoku small kana", "ちょっと", []int{3}},
+		{"emoji modifier", "\U0001F44D\U0001F3FD ok", []int{3}},
+		{"flags", "\U0001F1EF\U0001F1F5\U0001F1FA\U0001F1F8", []int{2}},
+		{"no break space", "10 km away", []int{6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for i, allowed := range tp.lineBreakOpportunities([]rune(tt.text)) {
+				if allowed {
+					got = append(got, i)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("lineBreakOpportunities(%q) = %v, want %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTextProcessor_SplitText_KeepsWords tests that words outside ASCII are not split across lines.
+func TestTextProcessor_SplitText_KeepsWords(t *testing.T) {
+	face := newTextConfigFace(parseTestFont(t), 32, newTestLayoutConfig())
+	tp := NewTextProcessor(nil, nil, 0)
+
+	for _, text := range []string{
+		"Café naïve résumé déjà façade",
+		"Привет прекрасный новый мир",
+		"한국어 문장을 단어 사이에서 나눕니다",
+	} {
+		lines := tp.SplitText(text, face, 240)
+		if len(lines) < 2 || strings.Join(lines, "") != text {
+			t.Fatalf("Expected %q to wrap into lines partitioning it, got %q", text, lines)
+		}
+		for _, line := range lines[:len(lines)-1] {
+			if !strings.HasSuffix(line, " ") {
+				t.Errorf("Expected lines of %q to break between words, got %q", text, lines)
+				break
+			}
+		}
+	}
+}
+
+// TestTextProcessor_SplitText_BreaksURLs tests that URLs break after slashes instead of anywhere.
+func TestTextProcessor_SplitText_BreaksURLs(t *testing.T) {
+	face := newTextConfigFace(parseTestFont(t), 32, newTestLayoutConfig())
+	tp := NewTextProcessor(nil, nil, 0)
+
+	text := "https://example.com/articles/unicode/line-breaking"
+	lines := tp.SplitText(text, face, 400)
+	if len(lines) < 2 || strings.Join(lines, "") != text {
+		t.Fatalf("Expected the URL to wrap, got %q", lines)
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if !strings.HasSuffix(line, "/") && !strings.HasSuffix(line, "-") {
+			t.Errorf("Expected the URL to break after a slash or hyphen, got %q", lines)
+			break
+		}
+	}
+}
+
+// TestTextProcessor_SplitText_TrailingSpaceHangs tests that a space at the end of a line
+// does not push the line's last word to the next line, and is not measured.
+func TestTextProcessor_SplitText_TrailingSpaceHangs(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	textConfig.LetterSpacing = 0
+	face := newTextConfigFace(parseTestFont(t), 32, textConfig)
+	tp := NewTextProcessor(nil, nil, 0)
+
+	maxWidth := measureStringWithSpacing(face, "Hello", 0)
+	lines := tp.SplitText("Hello Hello", face, maxWidth)
+	if !reflect.DeepEqual(lines, []string{"Hello ", "Hello"}) {
+		t.Fatalf("Expected the space to stay on the first line, got %q", lines)
+	}
+
+	layout := measureTextLayout(face, 32, lines, textConfig)
+	if layout.LineWidths[0] != maxWidth || !layout.fitsIn(TextArea{Width: maxWidth, Height: layout.Height}) {
+		t.Errorf("Expected the first line measured without its space as %dpx, got %dpx", maxWidth, layout.LineWidths[0])
+	}
+}
+
+// TestTextProcessor_SplitText_EmergencyBreakKeepsGraphemes tests that text without break
+// opportunities is broken between grapheme clusters.
+func TestTextProcessor_SplitText_EmergencyBreakKeepsGraphemes(t *testing.T) {
+	face := newTextConfigFace(parseTestFont(t), 32, newTestLayoutConfig())
+	tp := NewTextProcessor(nil, nil, 0)
+
+	text := strings.Repeat("e\u0301", 30)
+	lines := tp.SplitText(text, face, 100)
+	if len(lines) < 2 || strings.Join(lines, "") != text {
+		t.Fatalf("Expected the text to be broken, got %q", lines)
+	}
+	for _, line := range lines {
+		if r := []rune(line)[0]; unicode.Is(unicode.Mn, r) {
+			t.Errorf("Expected no line to start with a combining mark, got %q", lines)
+			break
+		}
+	}
+}
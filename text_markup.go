@@ -0,0 +1,442 @@
+package main
+
+import (
+	"image"
+	"strings"
+	"unicode"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/math/fixed"
+)
+
+// markupStyle is a set of inline markup styles applied to a run of text.
+type markupStyle uint8
+
+const (
+	markupBold markupStyle = 1 << iota
+	markupItalic
+	markupCode
+	markupHighlight
+)
+
+// textRun is a piece of text drawn with one markup style and highlight rule.
+type textRun struct {
+	Text  string
+	Style markupStyle
+	Rule  int // Index of the matching highlight rule plus one (0 for none)
+}
+
+// markupToggles lists the markers that switch a style on and off, longest first
+// so that ** is not read as two italic markers.
+var markupToggles = []struct {
+	marker string
+	style  markupStyle
+}{
+	{"**", markupBold},
+	{"==", markupHighlight},
+	{"*", markupItalic},
+}
+
+// parseMarkup splits text into runs of **bold**, *italic*, `code` and ==highlight== text.
+// As in CommonMark, a marker opens a style only before a non-space and closes it only after one,
+// so "a * b * c" is drawn as is. A marker also only opens a style when a closing marker follows
+// that is not part of a longer marker. Code spans are taken literally, and a backslash escapes
+// the next marker character.
+func parseMarkup(text string) []textRun {
+	runes := []rune(text)
+
+	var runs []textRun
+	var current []rune
+	var style markupStyle
+	flush := func() {
+		if len(current) > 0 {
+			runs = append(runs, textRun{Text: string(current), Style: style})
+			current = nil
+		}
+	}
+
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+
+		if r == '\\' && i+1 < len(runes) && isMarkupChar(runes[i+1]) {
+			current = append(current, runes[i+1])
+			i += 2
+			continue
+		}
+
+		if r == '`' {
+			if end := indexRune(runes, i+1, '`'); end > i+1 {
+				flush()
+				runs = append(runs, textRun{Text: string(runes[i+1 : end]), Style: style | markupCode})
+				i = end + 1
+				continue
+			}
+		}
+
+		toggled := false
+		for _, toggle := range markupToggles {
+			if !hasRunesPrefix(runes[i:], toggle.marker) {
+				continue
+			}
+			width := len(toggle.marker)
+			if style&toggle.style != 0 {
+				if canCloseMarkup(runes, i) {
+					flush()
+					style &^= toggle.style
+					toggled = true
+				}
+			} else if canOpenMarkup(runes, i+width) && hasMarkupCloser(runes, i+width, toggle.marker) {
+				flush()
+				style |= toggle.style
+				toggled = true
+			}
+			if toggled {
+				i += width
+			}
+			break
+		}
+		if toggled {
+			continue
+		}
+
+		current = append(current, r)
+		i++
+	}
+	flush()
+
+	return runs
+}
+
+// isMarkupChar reports whether r is a marker character that a backslash can escape.
+func isMarkupChar(r rune) bool {
+	return r == '*' || r == '`' || r == '=' || r == '\\'
+}
+
+// indexRune returns the index of the first r in runes at or after start, or -1.
+func indexRune(runes []rune, start int, r rune) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasRunesPrefix reports whether runes begin with prefix.
+func hasRunesPrefix(runes []rune, prefix string) bool {
+	i := 0
+	for _, r := range prefix {
+		if i >= len(runes) || runes[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// canOpenMarkup reports whether the marker ending before end is followed by a non-space.
+func canOpenMarkup(runes []rune, end int) bool {
+	return end < len(runes) && !unicode.IsSpace(runes[end])
+}
+
+// canCloseMarkup reports whether the marker starting at start follows a non-space.
+func canCloseMarkup(runes []rune, start int) bool {
+	return start > 0 && !unicode.IsSpace(runes[start-1])
+}
+
+// hasMarkupCloser reports whether a marker that can close a style follows at or after start.
+// Escaped characters and code spans are skipped, as are longer markers containing marker.
+func hasMarkupCloser(runes []rune, start int, marker string) bool {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == '\\' {
+			i++
+			continue
+		}
+		if runes[i] == '`' {
+			if end := indexRune(runes, i+1, '`'); end > i+1 {
+				i = end
+				continue
+			}
+		}
+		for _, toggle := range markupToggles {
+			if !hasRunesPrefix(runes[i:], toggle.marker) {
+				continue
+			}
+			if toggle.marker == marker && canCloseMarkup(runes, i) {
+				return true
+			}
+			i += len(toggle.marker) - 1
+			break
+		}
+	}
+	return false
+}
+
+// groupRuns groups runes into runs of consecutive runes with the same format.
+// The format of each rune is a run without text.
+func groupRuns(runes []rune, formats []textRun) []textRun {
+	var runs []textRun
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && formats[j] == formats[i] {
+			j++
+		}
+		run := formats[i]
+		run.Text = string(runes[i:j])
+		runs = append(runs, run)
+		i = j
+	}
+	return runs
+}
+
+// runsText returns the text of the runs without markup.
+func runsText(runs []textRun) string {
+	var text strings.Builder
+	for _, run := range runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+// runStyle returns the colors of a run. A highlight rule takes precedence over markup; among markup
+// styles, code takes precedence over highlight, highlight over bold and bold over italic.
+// Empty colors are inherited from the next style.
+func runStyle(textConfig *TextConfig, run textRun) TextRunStyleConfig {
+	var result TextRunStyleConfig
+	if run.Rule > 0 && run.Rule <= len(textConfig.Highlights) {
+		rule := &textConfig.Highlights[run.Rule-1]
+		result = TextRunStyleConfig{Color: rule.Color, Background: rule.Background}
+	}
+
+	markup := &textConfig.Markup
+	for _, candidate := range []struct {
+		style markupStyle
+		run   *TextRunStyleConfig
+	}{
+		{markupCode, &markup.Code},
+		{markupHighlight, &markup.Highlight},
+		{markupBold, &markup.Bold},
+		{markupItalic, &markup.Italic},
+	} {
+		if run.Style&candidate.style == 0 {
+			continue
+		}
+		if result.Color == "" {
+			result.Color = candidate.run.Color
+		}
+		if result.Background == "" {
+			result.Background = candidate.run.Background
+		}
+	}
+	return result
+}
+
+// hasRunBackgrounds reports whether any of the runs is drawn with a background box.
+func hasRunBackgrounds(textConfig *TextConfig, runs []textRun) bool {
+	for _, run := range runs {
+		if runStyle(textConfig, run).Background != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// runSource returns the source painted into the glyphs of a run: its own color, or src
+// with the text color or fill when the run has no color or the color is invalid.
+func runSource(textConfig *TextConfig, run textRun, src image.Image) image.Image {
+	hex := runStyle(textConfig, run).Color
+	if hex == "" {
+		return src
+	}
+	c, err := parseHexColor(hex)
+	if err != nil {
+		DefaultLogger.Warning("Failed to parse run color '%s', using the text color: %v", hex, err)
+		return src
+	}
+	return image.NewUniform(c)
+}
+
+// runBackgroundInsets returns how far run backgrounds extend beyond the laid out lines.
+func runBackgroundInsets(fontSize float64) textEffectInsets {
+	padding := int(fontSize*MarkupBackgroundPadding + 0.5)
+	return textEffectInsets{Left: padding, Right: padding}
+}
+
+// drawRunBackgrounds draws a rounded box behind each styled run that has a background color.
+// Adjacent runs with the same background share one box, so that bold text inside a
+// highlight does not split the marker.
+func drawRunBackgrounds(dst *image.RGBA, layout *TextLayout, origins []image.Point, textConfig *TextConfig) {
+	metrics := layout.Face.Metrics()
+	ascent, descent := metrics.Ascent.Ceil(), metrics.Descent.Ceil()
+	padding := runBackgroundInsets(layout.FontSize).Left
+
+	for i, origin := range origins {
+		// Spaces at the end of a line are not drawn, so backgrounds stop before them
+		runs := trimTrailingSpaces(layout.Runs[i])
+		extents := runExtents(runs, fixed.I(origin.X), layout.LetterSpacing)
+
+		for start := 0; start < len(runs); {
+			background := runStyle(textConfig, runs[start].textRun).Background
+			end := start + 1
+			for end < len(runs) && runStyle(textConfig, runs[end].textRun).Background == background {
+				end++
+			}
+			if background != "" {
+				x0, x1 := extents[start][0].Floor(), extents[end-1][1].Ceil()
+				shape := ShapeConfig{
+					Type:    ShapeRoundedRect,
+					X:       x0 - padding,
+					Y:       origin.Y - ascent,
+					Width:   x1 - x0 + 2*padding,
+					Height:  ascent + descent,
+					Radius:  layout.FontSize * MarkupBackgroundRadius,
+					Fill:    background,
+					Opacity: 1,
+				}
+				if err := drawShape(dst, &shape); err != nil {
+					DefaultLogger.Warning("Failed to draw markup background: %v", err)
+				}
+			}
+			start = end
+		}
+	}
+}
+
+// runFaces creates the face of each run format at one font size on first use.
+type runFaces struct {
+	regular    *truetype.Font
+	code       *truetype.Font   // Font of code runs
+	ruleFonts  []*truetype.Font // Font of each highlight rule (nil keeps the font of the run)
+	size       float64
+	textConfig *TextConfig
+	faces      map[textRun]font.Face
+}
+
+// newRunFaces creates the faces of styled runs in the regular, code and highlight rule fonts.
+func newRunFaces(regular, code *truetype.Font, ruleFonts []*truetype.Font, size float64, textConfig *TextConfig) *runFaces {
+	return &runFaces{
+		regular:    regular,
+		code:       code,
+		ruleFonts:  ruleFonts,
+		size:       size,
+		textConfig: textConfig,
+		faces:      make(map[textRun]font.Face),
+	}
+}
+
+// face returns the face of a run. Bold raises the weight of the text element to bold
+// and italic slants it; both are synthesized when the font has no such style.
+// Runes missing from a code or highlight rule font are drawn in the font beneath it.
+func (rf *runFaces) face(run textRun) font.Face {
+	format := textRun{Style: run.Style, Rule: run.Rule}
+	if face, exists := rf.faces[format]; exists {
+		return face
+	}
+
+	fontStyle := textFontStyle(rf.textConfig)
+	if run.Style&markupBold != 0 && fontStyle.Weight < BoldFontWeight {
+		fontStyle.Weight = BoldFontWeight
+	}
+	if run.Style&markupItalic != 0 {
+		fontStyle.Italic = true
+	}
+
+	face := newTextFace(rf.regular, rf.size, fontStyle)
+	withFont := func(f *truetype.Font) {
+		if f != nil {
+			face = &fallbackFace{Face: newTextFace(f, rf.size, fontStyle), font: f, fallback: face}
+		}
+	}
+	if run.Style&markupCode != 0 {
+		withFont(rf.code)
+	}
+	if run.Rule > 0 && run.Rule <= len(rf.ruleFonts) {
+		withFont(rf.ruleFonts[run.Rule-1])
+	}
+	if !rf.textConfig.Kerning {
+		face = noKerningFace{face}
+	}
+
+	cached := newCachedFace(face)
+	rf.faces[format] = cached
+	return cached
+}
+
+// fallbackFace draws the runes missing from its font with a fallback face,
+// so that code runs in a Latin monospace font can still contain Japanese text.
+type fallbackFace struct {
+	font.Face
+	font     *truetype.Font
+	fallback font.Face
+}
+
+// pick returns the face that has a glyph for r.
+func (ff *fallbackFace) pick(r rune) font.Face {
+	if ff.font.Index(r) == 0 {
+		return ff.fallback
+	}
+	return ff.Face
+}
+
+// Glyph implements font.Face.
+func (ff *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return ff.pick(r).Glyph(dot, r)
+}
+
+// GlyphBounds implements font.Face.
+func (ff *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return ff.pick(r).GlyphBounds(r)
+}
+
+// GlyphAdvance implements font.Face.
+func (ff *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return ff.pick(r).GlyphAdvance(r)
+}
+
+// Kern implements font.Face. Pairs are only kerned when both glyphs come from the same face.
+func (ff *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	face := ff.pick(r0)
+	if face != ff.pick(r1) {
+		return 0
+	}
+	return face.Kern(r0, r1)
+}
+
+// markupCodeFont returns the font of code runs: the configured code font, or the embedded
+// Go Mono when none is configured or it cannot be loaded. The regular font is the last resort.
+func (ir *ImageRenderer) markupCodeFont(markup *TextMarkupConfig, regular *truetype.Font) *truetype.Font {
+	if markup.CodeFont != nil && *markup.CodeFont != "" {
+		if font := ir.loadRunFont(*markup.CodeFont); font != nil {
+			return font
+		}
+	}
+
+	if ir.monoFont == nil {
+		font, err := truetype.Parse(gomono.TTF)
+		if err != nil {
+			DefaultLogger.Warning("Failed to parse the embedded Go Mono font, using the text font for code: %v", err)
+			return regular
+		}
+		ir.monoFont = font
+	}
+	return ir.monoFont
+}
+
+// loadRunFont loads the font of styled runs from a path resolved by the article processor,
+// or returns nil if it cannot be loaded. Text is laid out at many sizes while shrinking,
+// so a failure is reported once per path.
+func (ir *ImageRenderer) loadRunFont(path string) *truetype.Font {
+	if ir.failedFonts[path] {
+		return nil
+	}
+	font, err := ir.fontManager.LoadFont(path, "")
+	if err != nil {
+		DefaultLogger.Warning("Failed to load font '%s' for styled text, using the text font: %v", path, err)
+		ir.failedFonts[path] = true
+		return nil
+	}
+	return font
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"image/color"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+// TestParseMarkup tests splitting marked up text into styled runs.
+func TestParseMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []textRun
+	}{
+		{
+			name:     "plain text",
+			text:     "Plain title",
+			expected: []textRun{{Text: "Plain title"}},
+		},
+		{
+			name: "bold and italic",
+			text: "A **bold** and *italic* word",
+			expected: []textRun{
+				{Text: "A "},
+				{Text: "bold", Style: markupBold},
+				{Text: " and "},
+				{Text: "italic", Style: markupItalic},
+				{Text: " word"},
+			},
+		},
+		{
+			name: "code is literal",
+			text: "Use `a**b` now",
+			expected: []textRun{
+				{Text: "Use "},
+				{Text: "a**b", Style: markupCode},
+				{Text: " now"},
+			},
+		},
+		{
+			name: "bold inside highlight",
+			text: "==new **Go** release==",
+			expected: []textRun{
+				{Text: "new ", Style: markupHighlight},
+				{Text: "Go", Style: markupHighlight | markupBold},
+				{Text: " release", Style: markupHighlight},
+			},
+		},
+		{
+			name: "bold italic",
+			text: "***both***",
+			expected: []textRun{
+				{Text: "both", Style: markupBold | markupItalic},
+			},
+		},
+		{
+			name:     "unclosed markers are literal",
+			text:     "5 * 3 = 15 and a `tick",
+			expected: []textRun{{Text: "5 * 3 = 15 and a `tick"}},
+		},
+		{
+			name:     "markers between spaces are literal",
+			text:     "a * b * c",
+			expected: []textRun{{Text: "a * b * c"}},
+		},
+		{
+			name: "closer consumed by a longer marker",
+			text: "*a **b**",
+			expected: []textRun{
+				{Text: "*a "},
+				{Text: "b", Style: markupBold},
+			},
+		},
+		{
+			name: "italic inside bold",
+			text: "**a *b* c**",
+			expected: []textRun{
+				{Text: "a ", Style: markupBold},
+				{Text: "b", Style: markupBold | markupItalic},
+				{Text: " c", Style: markupBold},
+			},
+		},
+		{
+			name: "escaped markers",
+			text: `\*not italic\* but *this*`,
+			expected: []textRun{
+				{Text: "*not italic* but "},
+				{Text: "this", Style: markupItalic},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMarkup(tt.text); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseMarkup(%q) = %+v, want %+v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTextProcessor_SplitRuns tests that lines break within and across runs without losing text or styles.
+func TestTextProcessor_SplitRuns(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 32
+	faces := newRunFaces(parseTestFont(t), parseTestFont(t), nil, textConfig.Size, textConfig)
+	tp := NewTextProcessor(nil, nil, 0)
+
+	runs := parseMarkup("Read the **very bold announcement** today\nsecond")
+	lines := tp.SplitRuns(runs, faces.face, 260)
+
+	if len(lines) < 3 {
+		t.Fatalf("Expected the paragraph to wrap, got %+v", lines)
+	}
+
+	var texts []string
+	boldText := ""
+	for _, line := range lines {
+		texts = append(texts, runsText(line))
+		for _, run := range line {
+			if run.Style == markupBold {
+				boldText += run.Text
+			}
+		}
+
+		width := runsWidth(layoutLineRuns(line, faces), 0).Ceil()
+		if width > 260 {
+			t.Errorf("Line %q is %dpx wide, exceeding 260px", runsText(line), width)
+		}
+	}
+
+	if joined := strings.Join(texts, ""); joined != "Read the very bold announcement todaysecond" {
+		t.Errorf("Expected the lines to partition the text, got %q", texts)
+	}
+	if boldText != "very bold announcement" {
+		t.Errorf("Expected the bold run to keep its style across lines, got %q", boldText)
+	}
+	if texts[len(texts)-1] != "second" {
+		t.Errorf("Expected a manual break before the last line, got %q", texts)
+	}
+}
+
+// layoutLineRuns pairs the runs of a line with their faces.
+func layoutLineRuns(runs []textRun, faces *runFaces) []layoutRun {
+	result := make([]layoutRun, len(runs))
+	for i, run := range runs {
+		result[i] = layoutRun{textRun: run, Face: faces.face(run)}
+	}
+	return result
+}
+
+// TestFallbackFace tests that runes missing from the code font use the fallback face.
+func TestFallbackFace(t *testing.T) {
+	mono, err := truetype.Parse(gomono.TTF)
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
+	}
+	primary := truetype.NewFace(mono, &truetype.Options{Size: 20})
+	fallback := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 20})
+	face := &fallbackFace{Face: primary, font: mono, fallback: fallback}
+
+	if got := face.pick('a'); got != font.Face(primary) {
+		t.Error("Expected the code font for 'a'")
+	}
+	if got := face.pick('日'); got != font.Face(fallback) {
+		t.Error("Expected the fallback face for a rune missing from the code font")
+	}
+	if kern := face.Kern('a', '日'); kern != 0 {
+		t.Errorf("Expected no kerning across faces, got %v", kern)
+	}
+}
+
+// TestRenderTextElement_MarkupRuns tests that runs are drawn with their own colors and backgrounds.
+func TestRenderTextElement_MarkupRuns(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	red := color.RGBA{R: 255, A: 255}
+	yellow := color.RGBA{R: 255, G: 224, B: 102, A: 255}
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Color = "#000000"
+	textConfig.Size = 40
+	textConfig.Overflow = OverflowClip
+	textConfig.BlockPosition = "top-left"
+	textConfig.Area = TextArea{X: 20, Y: 20, Width: 460, Height: 100}
+	textConfig.Markup.Enabled = true
+	textConfig.Markup.Bold.Color = "#FF0000"
+
+	img := newFilledRGBA(500, 140, white)
+	if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, "Plain **Bold** ==Marked==", false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	counts := make(map[color.RGBA]int)
+	for y := 0; y < img.Rect.Dy(); y++ {
+		for x := 0; x < img.Rect.Dx(); x++ {
+			counts[img.RGBAAt(x, y)]++
+		}
+	}
+	for name, c := range map[string]color.RGBA{"black": {A: 255}, "red": red, "yellow": yellow} {
+		if counts[c] == 0 {
+			t.Errorf("Expected %s pixels in the rendered text", name)
+		}
+	}
+
+	ink := inkBounds(img, white)
+	if ink.Min.X < textConfig.Area.X || ink.Max.X > textConfig.Area.X+textConfig.Area.Width {
+		t.Errorf("Expected the text and backgrounds within area %+v, got ink at %v", textConfig.Area, ink)
+	}
+}
+
+// TestRenderTextElement_MarkupDisabled tests that markers are drawn as is unless markup is enabled.
+func TestRenderTextElement_MarkupDisabled(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	renderer := NewImageRenderer()
+
+	inkWidth := func(enabled bool) int {
+		textConfig := newTestLayoutConfig()
+		textConfig.Color = "#000000"
+		textConfig.Size = 40
+		textConfig.Overflow = OverflowClip
+		textConfig.Area = TextArea{X: 20, Y: 20, Width: 460, Height: 100}
+		textConfig.Markup.Enabled = enabled
+
+		img := newFilledRGBA(500, 140, white)
+		if err := renderer.RenderTextElement(img, parseTestFont(t), textConfig, "*Slanted*", false, "title"); err != nil {
+			t.Fatalf("RenderTextElement failed: %v", err)
+		}
+		return inkBounds(img, white).Dx()
+	}
+
+	if plain, marked := inkWidth(false), inkWidth(true); marked >= plain {
+		t.Errorf("Expected the markers to be removed with markup, got widths %d and %d", plain, marked)
+	}
+}
+
+// TestArticleProcessor_ResolveCodeFont tests that code font paths fall back to the config directory.
+func TestArticleProcessor_ResolveCodeFont(t *testing.T) {
+	configDir := t.TempDir()
+	ap := &ArticleProcessor{configDir: configDir}
+
+	config := getDefaultConfig()
+	config.Title.Markup.CodeFont = stringPtr("fonts/mono.ttf")
+
+	ap.resolveTextAssetPaths(config, t.TempDir())
+
+	if expected := filepath.Join(configDir, "fonts/mono.ttf"); *config.Title.Markup.CodeFont != expected {
+		t.Errorf("Expected code font %s, got %s", expected, *config.Title.Markup.CodeFont)
+	}
+	if config.Description.Markup.CodeFont != nil {
+		t.Errorf("Expected no description code font, got %s", *config.Description.Markup.CodeFont)
+	}
+}
+
+// TestMergeConfigsWithSettings_Markup tests merging markup from global settings and front matter.
+func TestMergeConfigsWithSettings_Markup(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{
+			Markup: &TextMarkupSettings{
+				Enabled:  boolPtr(true),
+				CodeFont: stringPtr("mono.ttf"),
+				Bold:     &TextRunStyleSettings{Color: stringPtr("#FF5500")},
+			},
+		},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{
+			Markup: &TextMarkupSettings{
+				Highlight: &TextRunStyleSettings{Background: stringPtr("#A0E0FF")},
+			},
+		},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+	markup := config.Title.Markup
+
+	if !markup.Enabled || markup.CodeFont == nil || *markup.CodeFont != "mono.ttf" {
+		t.Errorf("Unexpected merged markup %+v", markup)
+	}
+	if markup.Bold.Color != "#FF5500" || markup.Highlight.Background != "#A0E0FF" {
+		t.Errorf("Unexpected merged run styles %+v", markup)
+	}
+	if markup.Code.Background != DefaultMarkupCodeBackground {
+		t.Errorf("Expected default code background, got %s", markup.Code.Background)
+	}
+	if config.Description.Markup.Enabled {
+		t.Error("Expected markup disabled for the description")
+	}
+}
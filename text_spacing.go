@@ -1,14 +1,23 @@
 package main
 
 import (
-	"github.com/golang/freetype"
+	"image"
+	"image/draw"
+
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
 )
 
-// measureStringWithSpacing calculates text width including letter spacing.
-// It measures each character individually and adds spacing between them.
+// measureStringWithSpacing calculates text width including letter spacing and kerning.
+// The width is accumulated in 26.6 fixed point and rounded up to whole pixels once.
 func measureStringWithSpacing(face font.Face, text string, letterSpacingPx int) int {
+	return measureStringWithSpacingFixed(face, text, letterSpacingPx).Ceil()
+}
+
+// measureStringWithSpacingFixed calculates text width in 26.6 fixed point.
+// Kerning between adjacent glyphs is taken from face.Kern, so faces that
+// should not kern must return zero (see noKerningFace).
+func measureStringWithSpacingFixed(face font.Face, text string, letterSpacingPx int) fixed.Int26_6 {
 	if text == "" {
 		return 0
 	}
@@ -19,44 +28,66 @@ func measureStringWithSpacing(face font.Face, text string, letterSpacingPx int)
 	}
 
 	// Measure character by character
-	totalWidth := 0
+	var totalWidth fixed.Int26_6
 	for i, r := range runes {
-		charWidth := font.MeasureString(face, string(r))
-		totalWidth += int(charWidth >> 6)
+		if i > 0 {
+			totalWidth += face.Kern(runes[i-1], r)
+		}
+
+		charWidth, _ := face.GlyphAdvance(r)
+		totalWidth += charWidth
 
 		// Add letter spacing between characters (not after the last character)
 		if i < len(runes)-1 {
-			totalWidth += letterSpacingPx
+			totalWidth += fixed.I(letterSpacingPx)
 		}
 	}
 
 	return totalWidth
 }
 
-// drawStringWithSpacing draws text with custom letter spacing.
-// It renders each character individually with the specified spacing.
-func drawStringWithSpacing(c *freetype.Context, face font.Face, text string, x, y int, letterSpacingPx int) error {
+// drawStringWithSpacing draws text with custom letter spacing and kerning.
+// Glyph positions advance in 26.6 fixed point; the face rounds them when rasterizing.
+func drawStringWithSpacing(dst draw.Image, src image.Image, face font.Face, text string, x, y int, letterSpacingPx int) {
+	drawStringWithSpacingFixed(dst, src, face, text, fixed.I(x), fixed.I(y), letterSpacingPx)
+}
+
+// drawStringWithSpacingFixed draws text starting at a 26.6 fixed point position.
+// The source is aligned with dst rather than with each glyph, so gradient and texture
+// sources paint the glyphs with the part of the source they cover.
+func drawStringWithSpacingFixed(dst draw.Image, src image.Image, face font.Face, text string, x, y fixed.Int26_6, letterSpacingPx int) {
 	if text == "" {
-		return nil
+		return
 	}
 
 	runes := []rune(text)
 	if len(runes) == 0 {
-		return nil
+		return
 	}
 
 	currentX := x
-	for _, r := range runes {
-		pt := fixed.Point26_6{X: fixed.I(currentX), Y: fixed.I(y)}
-		_, err := c.DrawString(string(r), pt)
-		if err != nil {
-			return err
+	for i, r := range runes {
+		if i > 0 {
+			currentX += face.Kern(runes[i-1], r)
+		}
+
+		dr, mask, maskp, _, ok := face.Glyph(fixed.Point26_6{X: currentX, Y: y}, r)
+		if ok {
+			draw.DrawMask(dst, dr, src, dr.Min, mask, maskp, draw.Over)
 		}
 
 		// Move to next character position
-		charWidth := font.MeasureString(face, string(r))
-		currentX += int(charWidth>>6) + letterSpacingPx
+		charWidth, _ := face.GlyphAdvance(r)
+		currentX += charWidth + fixed.I(letterSpacingPx)
 	}
+}
+
+// noKerningFace wraps a font face and disables kerning between glyph pairs.
+type noKerningFace struct {
+	font.Face
+}
 
-	return nil
+// Kern implements font.Face and always returns zero.
+func (noKerningFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return 0
 }
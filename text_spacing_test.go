@@ -0,0 +1,107 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// pairKerningFace is a test face that kerns a single glyph pair.
+type pairKerningFace struct {
+	font.Face
+	left, right rune
+	kern        fixed.Int26_6
+}
+
+func (f pairKerningFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if r0 == f.left && r1 == f.right {
+		return f.kern
+	}
+	return 0
+}
+
+func TestMeasureStringWithSpacing_Kerning(t *testing.T) {
+	base := truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 64})
+	kerned := pairKerningFace{Face: base, left: 'A', right: 'V', kern: fixed.I(-5)}
+
+	withKerning := measureStringWithSpacingFixed(kerned, "AV", 0)
+	withoutKerning := measureStringWithSpacingFixed(noKerningFace{kerned}, "AV", 0)
+
+	if withoutKerning-withKerning != fixed.I(5) {
+		t.Errorf("Kerning should tighten AV by 5px: kerned=%v unkerned=%v", withKerning, withoutKerning)
+	}
+
+	// Kerning only applies between adjacent glyphs
+	if measureStringWithSpacingFixed(kerned, "VA", 0) != measureStringWithSpacingFixed(base, "VA", 0) {
+		t.Error("Kerning should not apply to unrelated pairs")
+	}
+}
+
+func TestMeasureStringWithSpacing_NoRoundingDrift(t *testing.T) {
+	f := parseTestFont(t)
+	face := noKerningFace{truetype.NewFace(f, &truetype.Options{Size: 17})}
+
+	text := "iiiiiiiiiiiiiiiiiiii"
+	advance, _ := face.GlyphAdvance('i')
+	expected := (advance * fixed.Int26_6(len(text))).Ceil()
+
+	if got := measureStringWithSpacing(face, text, 0); got != expected {
+		t.Errorf("Expected width %d from fixed-point accumulation, got %d", expected, got)
+	}
+}
+
+func TestMeasureStringWithSpacing_LetterSpacing(t *testing.T) {
+	f := parseTestFont(t)
+	face := truetype.NewFace(f, &truetype.Options{Size: 32})
+
+	base := measureStringWithSpacing(face, "abc", 0)
+	spaced := measureStringWithSpacing(face, "abc", 5)
+
+	// Spacing is added between characters only
+	if spaced-base != 10 {
+		t.Errorf("Expected letter spacing to add 10px, got %d", spaced-base)
+	}
+	if measureStringWithSpacing(face, "", 5) != 0 {
+		t.Error("Empty string should measure zero")
+	}
+}
+
+func TestDrawStringWithSpacing_MatchesMeasurement(t *testing.T) {
+	f := parseTestFont(t)
+	face := truetype.NewFace(f, &truetype.Options{Size: 40})
+	img := image.NewRGBA(image.Rect(0, 0, 400, 100))
+
+	text := "AVTo"
+	drawStringWithSpacing(img, image.NewUniform(color.Black), face, text, 10, 60, 0)
+
+	// Find the rightmost inked column
+	rightmost := -1
+	for x := img.Bounds().Max.X - 1; x >= 0 && rightmost < 0; x-- {
+		for y := 0; y < img.Bounds().Max.Y; y++ {
+			if img.RGBAAt(x, y).A > 0 {
+				rightmost = x
+				break
+			}
+		}
+	}
+
+	width := measureStringWithSpacing(face, text, 0)
+	lastBounds, _, _ := face.GlyphBounds('o')
+	lastAdvance, _ := face.GlyphAdvance('o')
+	// The ink of the last glyph ends at most its right side bearing before the advance
+	maxInk := 10 + width - (lastAdvance - lastBounds.Max.X).Floor()
+	if rightmost < 10 || rightmost > maxInk {
+		t.Errorf("Drawn text should end within measured width: rightmost=%d limit=%d", rightmost, maxInk)
+	}
+}
+
+func TestNoKerningFace(t *testing.T) {
+	var face font.Face = noKerningFace{truetype.NewFace(parseTestFont(t), &truetype.Options{Size: 64})}
+	if face.Kern('A', 'V') != 0 {
+		t.Error("noKerningFace should never kern")
+	}
+}
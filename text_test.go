@@ -1,6 +1,7 @@
 package main
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/golang/freetype/truetype"
@@ -84,206 +85,128 @@ func TestTextProcessor_SplitText_JapaneseLineBreaking(t *testing.T) {
 	}
 }
 
-func TestTextProcessor_handleStartProhibited(t *testing.T) {
-	startProhibited := map[rune]bool{
-		'。': true,
-		'、': true,
-	}
-	endProhibited := make(map[rune]bool)
-
-	tp := NewTextProcessor(startProhibited, endProhibited, 0)
-
-	// Test data
-	lines := []string{"first line"}
-	currentLine := []rune("これはテスト")
-	runes := []rune("これはテスト。です")
-	i := 7 // Position of '。'
-	r := '。'
-
-	newLines, newCurrentLine, newIndex := tp.handleStartProhibited(lines, currentLine, runes, i, r)
-
-	// Should add the prohibited character to current line and finalize it
-	if len(newLines) != 2 {
-		t.Errorf("Expected 2 lines, got %d", len(newLines))
-	}
-
-	if len(newCurrentLine) != 0 {
-		t.Errorf("Expected empty current line after handling start prohibited, got %v", newCurrentLine)
-	}
-
-	if newIndex <= i {
-		t.Errorf("Expected index to advance, got %d", newIndex)
-	}
-}
-
-func TestTextProcessor_handleEndProhibited(t *testing.T) {
-	startProhibited := make(map[rune]bool)
-	endProhibited := map[rune]bool{
-		'（': true,
-		'「': true,
+func TestTextProcessor_SplitText_StartProhibited(t *testing.T) {
+	font, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
 	}
-
+	// Go Regular has no CJK glyphs, so every CJK character takes the 24px advance of the missing glyph
+	face := truetype.NewFace(font, &truetype.Options{Size: 32})
+	startProhibited, endProhibited := buildProhibitedMaps(newTestLayoutConfig())
 	tp := NewTextProcessor(startProhibited, endProhibited, 0)
 
 	tests := []struct {
-		name        string
-		lines       []string
-		currentLine []rune
-		r           rune
-		expectLines int
+		name     string
+		text     string
+		maxWidth int
+		expected []string
 	}{
 		{
-			name:        "multiple characters in line",
-			lines:       []string{"first line"},
-			currentLine: []rune("これは（"),
-			r:           'テ',
-			expectLines: 2,
+			name:     "hangs at the line end",
+			text:     "これはテスト。です",
+			maxWidth: 144,
+			expected: []string{"これはテスト。", "です"},
 		},
 		{
-			name:        "single prohibited character",
-			lines:       []string{"first line"},
-			currentLine: []rune("（"),
-			r:           'テ',
-			expectLines: 2,
+			name:     "consecutive prohibited characters hang",
+			text:     "これはテスト。」です",
+			maxWidth: 144,
+			expected: []string{"これはテスト。」", "です"},
+		},
+		{
+			name:     "last line",
+			text:     "機械学習の基礎を学ぶ。",
+			maxWidth: 128,
+			expected: []string{"機械学習の", "基礎を学ぶ。"},
+		},
+		{
+			name:     "with brackets",
+			text:     "これはテストです。次の文章「引用」です。",
+			maxWidth: 192,
+			expected: []string{"これはテストです。", "次の文章「引用」", "です。"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newLines, newCurrentLine := tp.handleEndProhibited(tt.lines, tt.currentLine, tt.r)
-
-			if len(newLines) != tt.expectLines {
-				t.Errorf("Expected %d lines, got %d", tt.expectLines, len(newLines))
-			}
-
-			if len(newCurrentLine) == 0 {
-				t.Error("Expected non-empty current line after handling end prohibited")
+			if lines := tp.SplitText(tt.text, face, tt.maxWidth); !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected lines %q, got %q", tt.expected, lines)
 			}
 		})
 	}
 }
 
-func TestTextProcessor_handleWordBoundary(t *testing.T) {
-	tp := NewTextProcessor(make(map[rune]bool), make(map[rune]bool), 0)
+func TestTextProcessor_SplitText_EndProhibited(t *testing.T) {
+	font, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
+	}
+	face := truetype.NewFace(font, &truetype.Options{Size: 32})
+	endProhibited := map[rune]bool{'（': true, '「': true}
+	tp := NewTextProcessor(make(map[rune]bool), endProhibited, 0)
 
 	tests := []struct {
-		name        string
-		lines       []string
-		currentLine []rune
-		r           rune
-		expectLines int
+		name     string
+		text     string
+		maxWidth int
+		expected []string
 	}{
 		{
-			name:        "word character boundary",
-			lines:       []string{},
-			currentLine: []rune("hello world test"),
-			r:           'a',
-			expectLines: 1,
+			name:     "moves to the next line",
+			text:     "これは（テスト",
+			maxWidth: 96,
+			expected: []string{"これは", "（テスト"},
 		},
 		{
-			name:        "non-word character",
-			lines:       []string{},
-			currentLine: []rune("test、"),
-			r:           'あ',
-			expectLines: 1,
-		},
-		{
-			name:        "empty current line",
-			lines:       []string{},
-			currentLine: []rune{},
-			r:           'a',
-			expectLines: 0,
+			name:     "single prohibited character breaks anyway",
+			text:     "（テスト",
+			maxWidth: 24,
+			expected: []string{"（", "テ", "ス", "ト"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			newLines, newCurrentLine := tp.handleWordBoundary(tt.lines, tt.currentLine, tt.r)
-
-			if len(newLines) != tt.expectLines {
-				t.Errorf("Expected %d lines, got %d", tt.expectLines, len(newLines))
-			}
-
-			// Should always have a current line with the new character
-			found := false
-			for _, char := range newCurrentLine {
-				if char == tt.r {
-					found = true
-					break
-				}
-			}
-			if !found {
-				t.Errorf("Expected character %c to be in current line %v", tt.r, newCurrentLine)
+			if lines := tp.SplitText(tt.text, face, tt.maxWidth); !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected lines %q, got %q", tt.expected, lines)
 			}
 		})
 	}
 }
 
-func TestIsWordChar(t *testing.T) {
-	tests := []struct {
-		char     rune
-		expected bool
-	}{
-		{'a', true},
-		{'Z', true},
-		{'0', true},
-		{'9', true},
-		{'_', true},
-		{'-', true},
-		{' ', false},
-		{'あ', false},
-		{'!', false},
-		{'@', false},
-	}
-
-	for _, tt := range tests {
-		t.Run(string(tt.char), func(t *testing.T) {
-			result := isWordChar(tt.char)
-			if result != tt.expected {
-				t.Errorf("Expected isWordChar(%c) = %v, got %v", tt.char, tt.expected, result)
-			}
-		})
+func TestTextProcessor_SplitText_WordBoundary(t *testing.T) {
+	font, err := truetype.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Failed to parse font: %v", err)
 	}
-}
+	face := truetype.NewFace(font, &truetype.Options{Size: 32})
+	tp := NewTextProcessor(make(map[rune]bool), make(map[rune]bool), 0)
 
-func TestFindWordBoundary(t *testing.T) {
 	tests := []struct {
 		name     string
-		runes    []rune
-		maxPos   int
-		expected int
+		text     string
+		fits     string // Text that just fits on a line
+		expected []string
 	}{
 		{
-			name:     "find boundary in middle",
-			runes:    []rune("hello world test"),
-			maxPos:   10, // 't' in "test"
-			expected: 5,  // space after "world"
-		},
-		{
-			name:     "boundary at start",
-			runes:    []rune("word"),
-			maxPos:   2,
-			expected: 2, // fallback to maxPos
-		},
-		{
-			name:     "non-word character at maxPos",
-			runes:    []rune("hello, world"),
-			maxPos:   5, // comma
-			expected: 5,
+			name:     "word character boundary",
+			text:     "hello world testa",
+			fits:     "hello world test",
+			expected: []string{"hello world ", "testa"},
 		},
 		{
-			name:     "maxPos out of bounds",
-			runes:    []rune("test"),
-			maxPos:   10,
-			expected: 3, // adjusted to bounds
+			name:     "non-word character",
+			text:     "test、あ",
+			fits:     "test、",
+			expected: []string{"test、", "あ"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := findWordBoundary(tt.runes, tt.maxPos)
-			if result != tt.expected {
-				t.Errorf("Expected %d, got %d", tt.expected, result)
+			maxWidth := measureStringWithSpacing(face, tt.fits, 0)
+			if lines := tp.SplitText(tt.text, face, maxWidth); !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected lines %q, got %q", tt.expected, lines)
 			}
 		})
 	}
@@ -352,49 +275,3 @@ func TestCalculateTextPosition(t *testing.T) {
 		})
 	}
 }
-
-func TestTextProcessor_fitsInWidth(t *testing.T) {
-	tp := NewTextProcessor(make(map[rune]bool), make(map[rune]bool), 0)
-
-	// Load a font for testing
-	font, err := truetype.Parse(goregular.TTF)
-	if err != nil {
-		t.Fatalf("Failed to parse font: %v", err)
-	}
-	face := truetype.NewFace(font, &truetype.Options{Size: 12})
-
-	tests := []struct {
-		name     string
-		testLine []rune
-		maxWidth int
-		expected bool
-	}{
-		{
-			name:     "short text fits",
-			testLine: []rune("Hi"),
-			maxWidth: 1000,
-			expected: true,
-		},
-		{
-			name:     "long text doesn't fit",
-			testLine: []rune("This is a very long line that should not fit"),
-			maxWidth: 50,
-			expected: false,
-		},
-		{
-			name:     "empty text fits",
-			testLine: []rune(""),
-			maxWidth: 100,
-			expected: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := tp.fitsInWidth(tt.testLine, face, tt.maxWidth)
-			if result != tt.expected {
-				t.Errorf("Expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
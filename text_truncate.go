@@ -0,0 +1,141 @@
+package main
+
+import (
+	"unicode"
+)
+
+// withinLines reports whether the layout has no more lines than maxLines (0 for no limit).
+func (l *TextLayout) withinLines(maxLines int) bool {
+	return maxLines <= 0 || len(l.Lines) <= maxLines
+}
+
+// visibleLines returns how many lines fit within the height and the maxLines limit.
+// At least one line is kept, so that truncated text is never dropped entirely.
+func (l *TextLayout) visibleLines(height, maxLines int) int {
+	lines := 1
+	if l.LineHeight > 0 && height/l.LineHeight > lines {
+		lines = height / l.LineHeight
+	}
+	if maxLines > 0 && maxLines < lines {
+		lines = maxLines
+	}
+	return lines
+}
+
+// truncate keeps the first n lines of the layout. When lines are dropped and the ellipsis
+// is not empty, the last kept line is shortened until it fits maxWidth with the ellipsis appended.
+func (l *TextLayout) truncate(n int, ellipsis string, maxWidth int) {
+	if n >= len(l.Lines) {
+		return
+	}
+
+	l.Lines = l.Lines[:n]
+	l.LineWidths = l.LineWidths[:n]
+	if l.Runs != nil {
+		l.Runs = l.Runs[:n]
+	}
+	if ellipsis != "" && n > 0 {
+		l.ellipsize(n-1, ellipsis, maxWidth)
+	}
+
+	l.Width = 0
+	for _, width := range l.LineWidths {
+		if width > l.Width {
+			l.Width = width
+		}
+	}
+	l.Height = n * l.LineHeight
+}
+
+// ellipsize removes grapheme clusters and trailing spaces from the end of line i until the line
+// fits maxWidth with the ellipsis appended. The ellipsis takes the style of the last kept run.
+func (l *TextLayout) ellipsize(i int, ellipsis string, maxWidth int) {
+	runes := []rune(l.Lines[i])
+	n := len(runes)
+	for {
+		for n > 0 && unicode.IsSpace(runes[n-1]) {
+			n--
+		}
+
+		text := string(runes[:n]) + ellipsis
+		var runs []layoutRun
+		var width int
+		if l.Runs == nil {
+			width = measureStringWithSpacing(l.Face, text, l.LetterSpacing)
+		} else {
+			runs = truncateRuns(l.Runs[i], n)
+			mark := layoutRun{textRun: textRun{Text: ellipsis}, Face: l.Face}
+			if len(runs) > 0 {
+				last := runs[len(runs)-1]
+				mark = layoutRun{textRun: textRun{Text: ellipsis, Style: last.Style, Rule: last.Rule}, Face: last.Face}
+			}
+			runs = append(runs, mark)
+			width = runsWidth(runs, l.LetterSpacing).Ceil()
+		}
+
+		if n == 0 || width <= maxWidth {
+			l.Lines[i] = text
+			l.LineWidths[i] = width
+			if l.Runs != nil {
+				l.Runs[i] = runs
+			}
+			return
+		}
+		n = lastGraphemeStart(runes[:n])
+	}
+}
+
+// truncateRuns returns the runs cut after their first n runes.
+func truncateRuns(runs []layoutRun, n int) []layoutRun {
+	var result []layoutRun
+	for _, run := range runs {
+		if n <= 0 {
+			break
+		}
+		runes := []rune(run.Text)
+		if len(runes) > n {
+			run.Text = string(runes[:n])
+		}
+		n -= len(runes)
+		result = append(result, run)
+	}
+	return result
+}
+
+// lastGraphemeStart returns the index of the first rune of the last grapheme cluster.
+// It approximates extended grapheme clusters: combining marks, variation selectors, emoji
+// modifiers and zero width joiner sequences stay with their base, and regional indicators pair into flags.
+func lastGraphemeStart(runes []rune) int {
+	i := len(runes) - 1
+	for i > 0 && (isGraphemeExtend(runes[i]) || runes[i-1] == zeroWidthJoiner) {
+		i--
+	}
+
+	// Regional indicators form flags in pairs counted from the start of the sequence
+	if i > 0 && isRegionalIndicator(runes[i]) {
+		count := 0
+		for j := i; j >= 0 && isRegionalIndicator(runes[j]); j-- {
+			count++
+		}
+		if count%2 == 0 {
+			i--
+		}
+	}
+	return i
+}
+
+// zeroWidthJoiner joins emoji into a single grapheme cluster.
+const zeroWidthJoiner = '\u200D'
+
+// isGraphemeExtend reports whether r extends the grapheme cluster before it.
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == zeroWidthJoiner ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // Emoji skin tone modifiers
+		(r >= 0xE0020 && r <= 0xE007F) // Emoji tag sequences
+}
+
+// isRegionalIndicator reports whether r is a regional indicator symbol used in flags.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
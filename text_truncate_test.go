@@ -0,0 +1,183 @@
+package main
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// TestLastGraphemeStart tests that truncation never splits a grapheme cluster.
+func TestLastGraphemeStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{"latin", "abc", 2},
+		{"combining accent", "ae\u0301", 1},
+		{"combining dakuten", "あか\u3099", 1},
+		{"skin tone modifier", "a\U0001F44D\U0001F3FD", 1},
+		{"zero width joiner sequence", "x\U0001F468\u200D\U0001F469\u200D\U0001F467", 1},
+		{"variation selector", "a\u2764\uFE0F", 1},
+		{"flag pairs", "\U0001F1EF\U0001F1F5\U0001F1FA\U0001F1F8", 2},
+		{"single rune", "a", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastGraphemeStart([]rune(tt.text)); got != tt.expected {
+				t.Errorf("lastGraphemeStart(%q) = %d, want %d", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTextLayout_TruncateWithEllipsis tests that the last kept line ends with an ellipsis that fits.
+func TestTextLayout_TruncateWithEllipsis(t *testing.T) {
+	layout := newTestLineLayout(t, "The first line", "The second line", "The third line")
+	maxWidth := layout.LineWidths[1]
+
+	layout.truncate(2, DefaultEllipsis, maxWidth)
+
+	if len(layout.Lines) != 2 || layout.Height != 2*layout.LineHeight {
+		t.Fatalf("Expected two lines, got %q with height %d", layout.Lines, layout.Height)
+	}
+	if last := layout.Lines[1]; !strings.HasSuffix(last, DefaultEllipsis) || !strings.HasPrefix(last, "The second") {
+		t.Errorf("Expected the second line shortened with an ellipsis, got %q", last)
+	}
+	if layout.LineWidths[1] > maxWidth || layout.Width > maxWidth {
+		t.Errorf("Expected the line to fit %dpx, got %dpx", maxWidth, layout.LineWidths[1])
+	}
+	if strings.Contains(layout.Lines[1], " "+DefaultEllipsis) {
+		t.Errorf("Expected no space before the ellipsis, got %q", layout.Lines[1])
+	}
+
+	// Text that is not cut keeps its lines unchanged
+	whole := newTestLineLayout(t, "Short")
+	whole.truncate(2, DefaultEllipsis, maxWidth)
+	if whole.Lines[0] != "Short" {
+		t.Errorf("Expected uncut text unchanged, got %q", whole.Lines)
+	}
+}
+
+// TestTextLayout_TruncateRuns tests that the ellipsis takes the style of the last kept run.
+func TestTextLayout_TruncateRuns(t *testing.T) {
+	textConfig := newTestLayoutConfig()
+	textConfig.Markup.Enabled = true
+	faces := newRunFaces(parseTestFont(t), parseTestFont(t), nil, 32, textConfig)
+	tp := NewTextProcessor(nil, nil, 0)
+
+	layout := layoutRuns(faces, parseMarkup("Plain **bold words that wrap** onto more lines"), textConfig, 200, tp)
+	if len(layout.Lines) < 3 {
+		t.Fatalf("Expected the text to wrap, got %q", layout.Lines)
+	}
+
+	layout.truncate(1, DefaultEllipsis, 200)
+
+	runs := layout.Runs[0]
+	last := runs[len(runs)-1]
+	if last.Text != DefaultEllipsis || last.Style != markupBold {
+		t.Errorf("Expected a bold ellipsis run, got %+v", runs)
+	}
+	if layout.Lines[0] != runsText(textRunsOf(runs)) {
+		t.Errorf("Expected the line text %q to match its runs", layout.Lines[0])
+	}
+}
+
+// textRunsOf returns the text runs of laid out runs.
+func textRunsOf(runs []layoutRun) []textRun {
+	result := make([]textRun, len(runs))
+	for i, run := range runs {
+		result[i] = run.textRun
+	}
+	return result
+}
+
+// TestRenderTextElement_EllipsisStaysInArea tests that ellipsis overflow drops the lines past the area.
+func TestRenderTextElement_EllipsisStaysInArea(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	text := "A very long description that keeps going well past the bottom of its small area"
+
+	render := func(overflow string) (*TextConfig, int) {
+		textConfig := newTestLayoutConfig()
+		textConfig.Color = "#000000"
+		textConfig.Size = 32
+		textConfig.Overflow = overflow
+		textConfig.BlockPosition = "top-left"
+		textConfig.Area = TextArea{X: 20, Y: 20, Width: 300, Height: 90}
+
+		img := newFilledRGBA(340, 400, white)
+		if err := NewImageRenderer().RenderTextElement(img, parseTestFont(t), textConfig, text, false, "description"); err != nil {
+			t.Fatalf("RenderTextElement failed: %v", err)
+		}
+		return textConfig, inkBounds(img, white).Max.Y
+	}
+
+	textConfig, clipped := render(OverflowClip)
+	bottom := textConfig.Area.Y + textConfig.Area.Height
+	if clipped <= bottom {
+		t.Fatalf("Expected clipped text to overflow the area, got ink to y=%d", clipped)
+	}
+
+	// The descent of the last line may reach slightly below the line box
+	if _, ellipsized := render(OverflowEllipsis); ellipsized > bottom+8 {
+		t.Errorf("Expected ellipsized text to end near the area bottom %d, got ink to y=%d", bottom, ellipsized)
+	}
+}
+
+// TestImageRenderer_ShrinkThenEllipsis tests that text is shrunk first and truncated at the minimum size.
+func TestImageRenderer_ShrinkThenEllipsis(t *testing.T) {
+	font := parseTestFont(t)
+	renderer := NewImageRenderer()
+
+	textConfig := newTestLayoutConfig()
+	textConfig.Size = 64
+	textConfig.MinSize = 24
+	textConfig.MaxLines = 2
+	startProhibited, endProhibited := buildProhibitedMaps(textConfig)
+	tp := NewTextProcessor(startProhibited, endProhibited, textConfig.LetterSpacing)
+	area := TextArea{Width: 400, Height: 400}
+
+	// Shrinking satisfies max_lines when possible
+	layout := renderer.adjustFontSizeToFit(font, "A title of medium length", textConfig, area, tp)
+	if len(layout.Lines) > 2 || layout.FontSize >= textConfig.Size {
+		t.Errorf("Expected a smaller size within two lines, got %.1f with %q", layout.FontSize, layout.Lines)
+	}
+
+	// Text that does not fit at the minimum size is truncated there
+	textConfig.Overflow = OverflowShrinkEllipsis
+	textConfig.Area = TextArea{X: 0, Y: 0, Width: 400, Height: 400}
+	img := newFilledRGBA(400, 400, color.RGBA{A: 255})
+	long := strings.Repeat("A remarkably long title ", 10)
+	if err := renderer.RenderTextElement(img, font, textConfig, long, false, "title"); err != nil {
+		t.Fatalf("RenderTextElement failed: %v", err)
+	}
+
+	layout = renderer.adjustFontSizeToFit(font, long, textConfig, area, tp)
+	if layout.FontSize != textConfig.MinSize {
+		t.Errorf("Expected the minimum size, got %.1f", layout.FontSize)
+	}
+	layout.truncate(layout.visibleLines(area.Height, textConfig.MaxLines), textConfig.Ellipsis, area.Width)
+	if len(layout.Lines) != 2 || !strings.HasSuffix(layout.Lines[1], DefaultEllipsis) {
+		t.Errorf("Expected two lines ending with an ellipsis, got %q", layout.Lines)
+	}
+}
+
+// TestMergeConfigsWithSettings_MaxLines tests merging max_lines and the ellipsis.
+func TestMergeConfigsWithSettings_MaxLines(t *testing.T) {
+	global := &ConfigSettings{
+		Title: &TextSettings{MaxLines: intPtr(3), Overflow: stringPtr(OverflowShrinkEllipsis)},
+	}
+	fm := &OGPFrontMatter{
+		Title: &TextConfigOverride{Ellipsis: stringPtr("...")},
+	}
+
+	config := NewConfigMerger().MergeConfigsWithSettings(getDefaultConfig(), global, nil, fm)
+
+	if config.Title.MaxLines != 3 || config.Title.Ellipsis != "..." || config.Title.Overflow != OverflowShrinkEllipsis {
+		t.Errorf("Unexpected merged title %d %q %s", config.Title.MaxLines, config.Title.Ellipsis, config.Title.Overflow)
+	}
+	if config.Description.MaxLines != 0 || config.Description.Ellipsis != DefaultEllipsis {
+		t.Errorf("Expected default description limits, got %d %q", config.Description.MaxLines, config.Description.Ellipsis)
+	}
+}